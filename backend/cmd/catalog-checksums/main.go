@@ -0,0 +1,207 @@
+// Command catalog-checksums fills in the SHA-256 and size of every artifact in
+// the model catalog by downloading it. Run it after adding or changing a
+// download URL:
+//
+//	go run ./cmd/catalog-checksums
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+
+	"alice-backend/internal/catalog"
+)
+
+var (
+	catalogPath = flag.String("catalog", "internal/catalog/catalog.json", "Path to the catalog file to update")
+	all         = flag.Bool("all", false, "Recompute artifacts that already have a checksum")
+	check       = flag.Bool("check", false, "Only list artifacts without a checksum or size; exit with status 1 if there are any")
+)
+
+// entry is an artifact of the catalog with a readable name and a function
+// that stores a changed copy back into the catalog
+type entry struct {
+	name     string
+	artifact catalog.Artifact
+	save     func(catalog.Artifact)
+}
+
+// checksum is the digest and size of a downloaded file
+type checksum struct {
+	sha256 string
+	size   int64
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	data, err := os.ReadFile(*catalogPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c, err := catalog.Parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	entries := entriesOf(c)
+	if *check {
+		missing := 0
+		for _, e := range entries {
+			if e.artifact.SHA256 == "" || e.artifact.Size <= 0 {
+				fmt.Println(e.name)
+				missing++
+			}
+		}
+		if missing > 0 {
+			log.Fatalf("%d of %d artifacts have no checksum or size", missing, len(entries))
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Models share files such as the wake-word feature models; fetch each URL once
+	sums := make(map[string]checksum)
+	updated := 0
+	for _, e := range entries {
+		a := e.artifact
+		if !*all && a.SHA256 != "" && a.Size > 0 {
+			continue
+		}
+
+		sum, ok := sums[a.URLs[0]]
+		if !ok {
+			log.Printf("Downloading %s", e.name)
+			if sum, err = fetch(ctx, a.URLs); err != nil {
+				log.Fatalf("%s: %v", e.name, err)
+			}
+			sums[a.URLs[0]] = sum
+		}
+
+		if a.SHA256 != "" && a.SHA256 != sum.sha256 {
+			log.Printf("Warning: checksum of %s changed from %s to %s", e.name, a.SHA256, sum.sha256)
+		}
+		a.SHA256, a.Size = sum.sha256, sum.size
+		e.save(a)
+		updated++
+	}
+
+	if updated == 0 {
+		log.Println("Every artifact already has a checksum and size")
+		return
+	}
+	if err := write(*catalogPath, c); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Updated %d artifacts in %s", updated, *catalogPath)
+}
+
+// entriesOf returns every artifact of the catalog, binaries by platform name
+func entriesOf(c *catalog.Catalog) []entry {
+	var entries []entry
+	for i := range c.Binaries {
+		b := &c.Binaries[i]
+		platforms := make([]string, 0, len(b.Platforms))
+		for platform := range b.Platforms {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+		for _, platform := range platforms {
+			entries = append(entries, entry{
+				name:     fmt.Sprintf("binary %s (%s): %s", b.Name, platform, b.Platforms[platform].File),
+				artifact: b.Platforms[platform],
+				save:     func(a catalog.Artifact) { b.Platforms[platform] = a },
+			})
+		}
+	}
+	for i := range c.Models {
+		m := &c.Models[i]
+		for j := range m.Files {
+			entries = append(entries, entry{
+				name:     fmt.Sprintf("model %s/%s: %s", m.Service, m.Name, m.Files[j].File),
+				artifact: m.Files[j],
+				save:     func(a catalog.Artifact) { m.Files[j] = a },
+			})
+		}
+	}
+	for i := range c.Voices {
+		v := &c.Voices[i]
+		for j := range v.Files {
+			entries = append(entries, entry{
+				name:     fmt.Sprintf("voice %s: %s", v.Name, v.Files[j].File),
+				artifact: v.Files[j],
+				save:     func(a catalog.Artifact) { v.Files[j] = a },
+			})
+		}
+	}
+	return entries
+}
+
+// fetch downloads a file from the first mirror that works and hashes it
+func fetch(ctx context.Context, urls []string) (checksum, error) {
+	var lastErr error
+	for _, url := range urls {
+		sum, err := fetchOne(ctx, url)
+		if err == nil {
+			return sum, nil
+		}
+		log.Printf("  %s: %v", url, err)
+		lastErr = err
+	}
+	return checksum{}, lastErr
+}
+
+func fetchOne(ctx context.Context, url string) (checksum, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return checksum{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return checksum{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return checksum{}, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, resp.Body)
+	if err != nil {
+		return checksum{}, err
+	}
+	if resp.ContentLength > 0 && size != resp.ContentLength {
+		return checksum{}, fmt.Errorf("got %d of %d bytes", size, resp.ContentLength)
+	}
+	return checksum{sha256: hex.EncodeToString(hash.Sum(nil)), size: size}, nil
+}
+
+// write saves the catalog in the same layout as the checked-in file
+func write(path string, c *catalog.Catalog) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	if _, err := catalog.Parse(buf.Bytes()); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -6,14 +6,17 @@ toolchain go1.24.4
 
 require (
 	github.com/gorilla/mux v1.8.1
+	github.com/jfreymuth/oggvorbis v1.0.5
+	github.com/pion/opus v0.1.0
 	github.com/yalue/onnxruntime_go v1.21.0
+	golang.org/x/net v0.47.0
+	google.golang.org/grpc v1.78.0
+	google.golang.org/protobuf v1.36.11
 )
 
 require (
-	golang.org/x/net v0.47.0 // indirect
+	github.com/jfreymuth/vorbis v1.0.2 // indirect
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251029180050-ab9386a59fda // indirect
-	google.golang.org/grpc v1.78.0 // indirect
-	google.golang.org/protobuf v1.36.11 // indirect
 )
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"alice-backend/internal/vectorstore"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateCollectionRequest creates a vector collection; a zero dimension is
+// taken from the first record, and unset index parameters use the defaults
+type CreateCollectionRequest struct {
+	Name      string                  `json:"name"`
+	Dimension int                     `json:"dimension,omitempty"`
+	Index     vectorstore.IndexConfig `json:"index"`
+}
+
+// UpsertRecord is a record to add to a collection. Without an embedding the
+// text is embedded server-side.
+type UpsertRecord struct {
+	ID        string          `json:"id,omitempty"`
+	Text      string          `json:"text,omitempty"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	Embedding []float32       `json:"embedding,omitempty"`
+}
+
+// UpsertRecordsRequest adds or replaces records of a collection
+type UpsertRecordsRequest struct {
+	Records []UpsertRecord `json:"records"`
+}
+
+// DeleteRecordsRequest removes records from a collection
+type DeleteRecordsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// QueryCollectionRequest searches a collection by text or by embedding.
+// EfSearch overrides the index's search width; Exact scans every record.
+type QueryCollectionRequest struct {
+	Text      string    `json:"text,omitempty"`
+	Embedding []float32 `json:"embedding,omitempty"`
+	TopK      int       `json:"top_k,omitempty"`
+	EfSearch  int       `json:"ef_search,omitempty"`
+	Exact     bool      `json:"exact,omitempty"`
+}
+
+// readyVectorStore returns the vector store, or writes an error response and
+// returns nil if it is unavailable
+func (h *Handler) readyVectorStore(w http.ResponseWriter) *vectorstore.Store {
+	if !h.config.Features.Embeddings {
+		h.writeError(w, http.StatusServiceUnavailable, "Embeddings service is disabled")
+		return nil
+	}
+
+	store := h.modelManager.GetVectorStore()
+	if store == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Vector store is not available")
+		return nil
+	}
+	return store
+}
+
+// collection looks up the collection named in the URL, writing an error
+// response if it doesn't exist
+func (h *Handler) collection(w http.ResponseWriter, r *http.Request) *vectorstore.Collection {
+	store := h.readyVectorStore(w)
+	if store == nil {
+		return nil
+	}
+
+	collection, err := store.Collection(mux.Vars(r)["name"])
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return nil
+	}
+	return collection
+}
+
+// embedTexts embeds texts with the embeddings service
+func (h *Handler) embedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddingService := h.modelManager.GetEmbeddingService()
+	if embeddingService == nil || !embeddingService.IsReady() {
+		return nil, errEmbeddingsNotReady
+	}
+	return embeddingService.GenerateEmbeddings(ctx, texts)
+}
+
+var errEmbeddingsNotReady = errors.New("embeddings service is not ready; send embeddings with the records")
+
+// writeVectorStoreError maps vector store errors to status codes
+func (h *Handler) writeVectorStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, vectorstore.ErrCollectionNotFound):
+		h.writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, vectorstore.ErrCollectionExists):
+		h.writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, vectorstore.ErrInvalidName), errors.Is(err, vectorstore.ErrDimensionMismatch):
+		h.writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, errEmbeddingsNotReady):
+		h.writeError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// ListCollections lists the vector collections
+func (h *Handler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	store := h.readyVectorStore(w)
+	if store == nil {
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"collections": store.List(),
+	})
+}
+
+// CreateCollection creates an empty vector collection
+func (h *Handler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	store := h.readyVectorStore(w)
+	if store == nil {
+		return
+	}
+
+	var req CreateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := store.Create(req.Name, req.Dimension, req.Index)
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, collection.Info())
+}
+
+// GetCollection describes a vector collection
+func (h *Handler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	h.writeSuccess(w, collection.Info())
+}
+
+// DeleteCollection deletes a vector collection and its records
+func (h *Handler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	store := h.readyVectorStore(w)
+	if store == nil {
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	if err := store.Drop(name); err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"message": "Collection deleted",
+		"name":    name,
+	})
+}
+
+// UpsertRecords adds records to a collection or replaces those with the same ID
+func (h *Handler) UpsertRecords(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	var req UpsertRecordsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Records) == 0 {
+		h.writeError(w, http.StatusBadRequest, "Records are required")
+		return
+	}
+
+	// Embed every record that came without an embedding in one batch
+	records := make([]vectorstore.Record, len(req.Records))
+	var texts []string
+	var pending []int
+	for i, record := range req.Records {
+		records[i] = vectorstore.Record{
+			ID:       record.ID,
+			Text:     record.Text,
+			Metadata: record.Metadata,
+			Vector:   record.Embedding,
+		}
+		if len(record.Embedding) > 0 {
+			continue
+		}
+		if record.Text == "" {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Record %d needs a text or an embedding", i))
+			return
+		}
+		texts = append(texts, record.Text)
+		pending = append(pending, i)
+	}
+
+	if len(texts) > 0 {
+		embeddings, err := h.embedTexts(r.Context(), texts)
+		if err != nil {
+			h.writeVectorStoreError(w, err)
+			return
+		}
+		for j, i := range pending {
+			records[i].Vector = embeddings[j]
+		}
+	}
+
+	ids, err := collection.Upsert(records)
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"ids": ids,
+	})
+}
+
+// GetRecord returns a record of a collection
+func (h *Handler) GetRecord(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	record, ok := collection.Get(mux.Vars(r)["id"])
+	if !ok {
+		h.writeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	h.writeSuccess(w, record)
+}
+
+// DeleteRecord removes a record from a collection
+func (h *Handler) DeleteRecord(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	deleted, err := collection.Delete([]string{mux.Vars(r)["id"]})
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+	if deleted == 0 {
+		h.writeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"deleted": deleted,
+	})
+}
+
+// DeleteRecords removes several records from a collection
+func (h *Handler) DeleteRecords(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	var req DeleteRecordsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	deleted, err := collection.Delete(req.IDs)
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"deleted": deleted,
+	})
+}
+
+// QueryCollection returns the records most similar to a text or an embedding
+func (h *Handler) QueryCollection(w http.ResponseWriter, r *http.Request) {
+	collection := h.collection(w, r)
+	if collection == nil {
+		return
+	}
+
+	var req QueryCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	query := req.Embedding
+	if len(query) == 0 {
+		if req.Text == "" {
+			h.writeError(w, http.StatusBadRequest, "Text or embedding is required")
+			return
+		}
+		embeddings, err := h.embedTexts(r.Context(), []string{req.Text})
+		if err != nil {
+			h.writeVectorStoreError(w, err)
+			return
+		}
+		query = embeddings[0]
+	}
+
+	matches, err := collection.Query(query, vectorstore.QueryOptions{
+		TopK:     req.TopK,
+		EfSearch: req.EfSearch,
+		Exact:    req.Exact,
+	})
+	if err != nil {
+		h.writeVectorStoreError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"matches": matches,
+	})
+}
+
+// collectionRoutes lists the vector collection routes
+func (h *Handler) collectionRoutes() []Route {
+	return []Route{
+		{"GET", "/api/embeddings/collections", FeatureEmbeddings, "List vector collections", h.ListCollections},
+		{"POST", "/api/embeddings/collections", FeatureEmbeddings, "Create a vector collection", h.CreateCollection},
+		{"GET", "/api/embeddings/collections/{name}", FeatureEmbeddings, "Describe a vector collection", h.GetCollection},
+		{"DELETE", "/api/embeddings/collections/{name}", FeatureEmbeddings, "Delete a vector collection", h.DeleteCollection},
+		{"POST", "/api/embeddings/collections/{name}/records", FeatureEmbeddings, "Add or replace records, embedding their text server-side", h.UpsertRecords},
+		{"GET", "/api/embeddings/collections/{name}/records/{id}", FeatureEmbeddings, "Get a record", h.GetRecord},
+		{"DELETE", "/api/embeddings/collections/{name}/records/{id}", FeatureEmbeddings, "Delete a record", h.DeleteRecord},
+		{"POST", "/api/embeddings/collections/{name}/delete", FeatureEmbeddings, "Delete several records", h.DeleteRecords},
+		{"POST", "/api/embeddings/collections/{name}/query", FeatureEmbeddings, "Find the records most similar to a text or embedding", h.QueryCollection},
+	}
+}
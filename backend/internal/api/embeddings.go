@@ -2,9 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
-	"github.com/gorilla/mux"
+	"alice-backend/internal/minilm"
 )
 
 // EmbeddingRequest represents a single embedding request
@@ -28,6 +29,15 @@ type BatchEmbeddingResponse struct {
 	Embeddings [][]float32 `json:"embeddings"`
 }
 
+// DocumentEmbeddingRequest represents a request to embed a long text. Pooling
+// is "mean" (default), "max", or "none" for one embedding per chunk.
+type DocumentEmbeddingRequest struct {
+	Text        string `json:"text"`
+	ChunkTokens int    `json:"chunk_tokens,omitempty"`
+	Overlap     int    `json:"overlap,omitempty"`
+	Pooling     string `json:"pooling,omitempty"`
+}
+
 // SimilarityRequest represents a similarity computation request
 type SimilarityRequest struct {
 	Embedding1 []float32 `json:"embedding1"`
@@ -123,6 +133,47 @@ func (h *Handler) GenerateEmbeddings(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// EmbedDocument handles embedding a text longer than the model's input limit
+func (h *Handler) EmbedDocument(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.Embeddings {
+		h.writeError(w, http.StatusServiceUnavailable, "Embeddings service is disabled")
+		return
+	}
+
+	embeddingService := h.modelManager.GetEmbeddingService()
+	if embeddingService == nil || !embeddingService.IsReady() {
+		h.writeError(w, http.StatusServiceUnavailable, "Embeddings service is not ready")
+		return
+	}
+
+	var req DocumentEmbeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Text == "" {
+		h.writeError(w, http.StatusBadRequest, "Text is required")
+		return
+	}
+
+	doc, err := embeddingService.EmbedDocument(r.Context(), req.Text, minilm.DocumentOptions{
+		ChunkTokens: req.ChunkTokens,
+		Overlap:     req.Overlap,
+		Pooling:     minilm.Pooling(req.Pooling),
+	})
+	if errors.Is(err, minilm.ErrInvalidDocumentOptions) {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Document embedding failed: "+err.Error())
+		return
+	}
+
+	h.writeSuccess(w, doc)
+}
+
 // ComputeSimilarity handles similarity computation
 func (h *Handler) ComputeSimilarity(w http.ResponseWriter, r *http.Request) {
 	if !h.config.Features.Embeddings {
@@ -204,30 +255,16 @@ func (h *Handler) SearchSimilar(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetEmbeddingsInfo returns embeddings service information
-func (h *Handler) GetEmbeddingsInfo(w http.ResponseWriter, r *http.Request) {
-	if !h.config.Features.Embeddings {
-		h.writeError(w, http.StatusServiceUnavailable, "Embeddings service is disabled")
-		return
-	}
-
-	embeddingService := h.modelManager.GetEmbeddingService()
-	if embeddingService == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "Embeddings service not available")
-		return
+// embeddingsRoutes lists the embedding routes
+func (h *Handler) embeddingsRoutes() []Route {
+	return []Route{
+		{"POST", "/api/embeddings/generate", FeatureEmbeddings, "Generate the embedding of a text", h.GenerateEmbedding},
+		{"POST", "/api/embeddings/batch", FeatureEmbeddings, "Generate the embeddings of several texts", h.GenerateEmbeddings},
+		{"POST", "/api/embeddings/generate-batch", FeatureEmbeddings, "Alias of /api/embeddings/batch", h.GenerateEmbeddings},
+		{"POST", "/api/embeddings/document", FeatureEmbeddings, "Embed a long text as overlapping chunks, pooled or per chunk", h.EmbedDocument},
+		{"POST", "/api/embeddings/similarity", FeatureEmbeddings, "Compute the cosine similarity of two embeddings", h.ComputeSimilarity},
+		{"POST", "/api/embeddings/search", FeatureEmbeddings, "Rank candidate embeddings by similarity to a query", h.SearchSimilar},
+		{"GET", "/api/embeddings/ready", FeatureEmbeddings, "Report whether the embeddings service is ready", h.EmbeddingsReady},
+		{"GET", "/api/embeddings/info", FeatureEmbeddings, "Embeddings service information", h.EmbeddingsInfo},
 	}
-
-	info := embeddingService.GetInfo()
-	h.writeSuccess(w, info)
-}
-
-// RegisterEmbeddingsRoutes registers embeddings-related routes
-func (h *Handler) RegisterEmbeddingsRoutes(router *mux.Router) {
-	embeddingsRouter := router.PathPrefix("/api/embeddings").Subrouter()
-
-	embeddingsRouter.HandleFunc("/generate", h.GenerateEmbedding).Methods("POST")
-	embeddingsRouter.HandleFunc("/batch", h.GenerateEmbeddings).Methods("POST")
-	embeddingsRouter.HandleFunc("/similarity", h.ComputeSimilarity).Methods("POST")
-	embeddingsRouter.HandleFunc("/search", h.SearchSimilar).Methods("POST")
-	embeddingsRouter.HandleFunc("/info", h.GetEmbeddingsInfo).Methods("GET")
 }
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"alice-backend/internal/events"
+	"alice-backend/internal/models"
+)
+
+// sseHeartbeatInterval keeps idle event streams from being closed by proxies
+const sseHeartbeatInterval = 15 * time.Second
+
+// ModelEvents streams download progress, extraction steps and service
+// readiness changes to the client as Server-Sent Events
+func (h *Handler) ModelEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to configure event stream")
+		return
+	}
+
+	// Subscribe before sending the snapshot so no change falls in between
+	eventCh, unsubscribe := events.Default().Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Send the current state first so clients don't need a separate /status call
+	snapshot := events.Event{
+		Type: "status",
+		Data: ModelsStatusResponse{
+			STT:        h.modelStatus(models.ServiceSTT),
+			TTS:        h.modelStatus(models.ServiceTTS),
+			Embeddings: h.modelStatus(models.ServiceEmbeddings),
+			WakeWord:   h.modelStatus(models.ServiceWakeWord),
+		},
+		Time: time.Now(),
+	}
+	if err := writeSSEEvent(w, snapshot); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeSSEEvent writes a single event in text/event-stream format
+func writeSSEEvent(w http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
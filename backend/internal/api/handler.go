@@ -57,6 +57,7 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 			"stt":        h.modelManager.GetSTTService() != nil && h.modelManager.GetSTTService().IsReady(),
 			"tts":        h.modelManager.GetTTSService() != nil && h.modelManager.GetTTSService().IsReady(),
 			"embeddings": h.modelManager.GetEmbeddingService() != nil && h.modelManager.GetEmbeddingService().IsReady(),
+			"wakeword":   h.modelManager.GetWakeWordService() != nil && h.modelManager.GetWakeWordService().IsReady(),
 		},
 	}
 	h.writeSuccess(w, response)
@@ -67,6 +68,15 @@ func (h *Handler) GetConfig(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, h.config)
 }
 
+// coreRoutes lists the routes that don't belong to a service
+func (h *Handler) coreRoutes() []Route {
+	return []Route{
+		{"GET", "/api/health", "", "Health of the backend and its services", h.HealthCheck},
+		{"GET", "/api/config", "", "Current configuration", h.GetConfig},
+		{"GET", "/api/routes", "", "Index of the API routes", h.ListRoutes},
+	}
+}
+
 // STTReady checks if STT service is ready
 func (h *Handler) STTReady(w http.ResponseWriter, r *http.Request) {
 	if !h.config.Features.STT {
@@ -1,5 +1,7 @@
 package api
 
+import "alice-backend/internal/models"
+
 // Request and response models for API endpoints
 
 // TranscriptionRequest represents a speech-to-text request
@@ -50,9 +52,15 @@ type VoicesResponse struct {
 
 // ModelStatus represents the status of a model
 type ModelStatus struct {
-	Installed   bool   `json:"installed"`
-	Downloading bool   `json:"downloading"`
-	Error       string `json:"error,omitempty"`
+	Installed       bool    `json:"installed"`
+	Downloading     bool    `json:"downloading"`
+	Model           string  `json:"model,omitempty"`
+	Status          string  `json:"status,omitempty"`
+	BytesDownloaded int64   `json:"bytes_downloaded,omitempty"`
+	TotalBytes      int64   `json:"total_bytes,omitempty"`
+	Progress        float64 `json:"progress,omitempty"`
+	SpeedBps        float64 `json:"speed_bps,omitempty"`
+	Error           string  `json:"error,omitempty"`
 }
 
 // ModelsStatusResponse represents the status of all models
@@ -60,18 +68,21 @@ type ModelsStatusResponse struct {
 	STT        ModelStatus `json:"stt"`
 	TTS        ModelStatus `json:"tts"`
 	Embeddings ModelStatus `json:"embeddings"`
+	WakeWord   ModelStatus `json:"wakeword"`
 }
 
 // DownloadModelRequest represents a model download request
 type DownloadModelRequest struct {
 	Service string `json:"service"`
+	Model   string `json:"model,omitempty"` // Voice name for the TTS service
 }
 
 // DownloadModelResponse represents a model download response
 type DownloadModelResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Success bool                `json:"success"`
+	Message string              `json:"message,omitempty"`
+	Error   string              `json:"error,omitempty"`
+	Job     *models.DownloadJob `json:"job,omitempty"`
 }
 
 // DownloadStatusResponse represents the download status response
@@ -79,6 +90,7 @@ type DownloadStatusResponse struct {
 	STT        ModelStatus `json:"stt"`
 	TTS        ModelStatus `json:"tts"`
 	Embeddings ModelStatus `json:"embeddings"`
+	WakeWord   ModelStatus `json:"wakeword"`
 }
 
 // HealthResponse represents the health check response
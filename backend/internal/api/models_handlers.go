@@ -2,8 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
+	"alice-backend/internal/models"
+	"alice-backend/internal/whisper"
+
 	"github.com/gorilla/mux"
 )
 
@@ -13,14 +18,35 @@ func (h *Handler) DownloadModel(w http.ResponseWriter, r *http.Request) {
 	service := vars["service"]
 
 	var req DownloadModelRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
 		h.writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
+	h.startDownload(w, service, req.Model)
+}
+
+// startDownload starts a background model download and writes the response
+func (h *Handler) startDownload(w http.ResponseWriter, service, model string) {
+	job, err := h.modelManager.StartDownload(service, model)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrUnknownService), errors.Is(err, whisper.ErrUnknownModel):
+			h.writeError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, models.ErrServiceDisabled):
+			h.writeError(w, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, models.ErrDownloadInProgress):
+			h.writeError(w, http.StatusConflict, err.Error())
+		default:
+			h.writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
 	response := DownloadModelResponse{
 		Success: true,
 		Message: "Model download started for service: " + service,
+		Job:     job,
 	}
 
 	h.writeSuccess(w, response)
@@ -29,18 +55,10 @@ func (h *Handler) DownloadModel(w http.ResponseWriter, r *http.Request) {
 // GetModelStatus returns the status of all models
 func (h *Handler) GetModelStatus(w http.ResponseWriter, r *http.Request) {
 	response := ModelsStatusResponse{
-		STT: ModelStatus{
-			Installed:   h.modelManager.GetSTTService() != nil && h.modelManager.GetSTTService().IsReady(),
-			Downloading: false,
-		},
-		TTS: ModelStatus{
-			Installed:   h.modelManager.GetTTSService() != nil && h.modelManager.GetTTSService().IsReady(),
-			Downloading: false,
-		},
-		Embeddings: ModelStatus{
-			Installed:   h.modelManager.GetEmbeddingService() != nil && h.modelManager.GetEmbeddingService().IsReady(),
-			Downloading: false,
-		},
+		STT:        h.modelStatus(models.ServiceSTT),
+		TTS:        h.modelStatus(models.ServiceTTS),
+		Embeddings: h.modelStatus(models.ServiceEmbeddings),
+		WakeWord:   h.modelStatus(models.ServiceWakeWord),
 	}
 
 	h.writeSuccess(w, response)
@@ -49,19 +67,44 @@ func (h *Handler) GetModelStatus(w http.ResponseWriter, r *http.Request) {
 // GetModelDownloadStatus returns the download status of all models
 func (h *Handler) GetModelDownloadStatus(w http.ResponseWriter, r *http.Request) {
 	response := DownloadStatusResponse{
-		STT: ModelStatus{
-			Installed:   h.modelManager.GetSTTService() != nil && h.modelManager.GetSTTService().IsReady(),
-			Downloading: false,
-		},
-		TTS: ModelStatus{
-			Installed:   h.modelManager.GetTTSService() != nil && h.modelManager.GetTTSService().IsReady(),
-			Downloading: false,
-		},
-		Embeddings: ModelStatus{
-			Installed:   h.modelManager.GetEmbeddingService() != nil && h.modelManager.GetEmbeddingService().IsReady(),
-			Downloading: false,
-		},
+		STT:        h.modelStatus(models.ServiceSTT),
+		TTS:        h.modelStatus(models.ServiceTTS),
+		Embeddings: h.modelStatus(models.ServiceEmbeddings),
+		WakeWord:   h.modelStatus(models.ServiceWakeWord),
 	}
 
 	h.writeSuccess(w, response)
 }
+
+// modelStatus combines the installation state of a service with its latest download job
+func (h *Handler) modelStatus(service string) ModelStatus {
+	status := ModelStatus{
+		Installed: h.modelManager.IsModelInstalled(service),
+	}
+
+	job, exists := h.modelManager.GetDownloadJob(service)
+	if !exists {
+		return status
+	}
+
+	status.Downloading = job.Active()
+	status.Model = job.Model
+	status.Status = job.Status
+	status.BytesDownloaded = job.BytesDownloaded
+	status.TotalBytes = job.TotalBytes
+	status.Progress = job.Progress()
+	status.SpeedBps = job.SpeedBps
+	status.Error = job.Error
+
+	return status
+}
+
+// modelRoutes lists the model management routes
+func (h *Handler) modelRoutes() []Route {
+	return []Route{
+		{"POST", "/api/models/download/{service}", "", "Download the model of a service in the background", h.DownloadModel},
+		{"GET", "/api/models/status", "", "Installation and download state of every model", h.GetModelStatus},
+		{"GET", "/api/models/download-status", "", "Download state of every model", h.GetModelDownloadStatus},
+		{"GET", "/api/models/events", "", "Download progress and service readiness as Server-Sent Events", h.ModelEvents},
+	}
+}
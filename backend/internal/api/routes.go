@@ -0,0 +1,96 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Features that gate routes, matching the flags in config.FeaturesConfig
+const (
+	FeatureSTT        = "stt"
+	FeatureTTS        = "tts"
+	FeatureEmbeddings = "embeddings"
+	FeatureWakeWord   = "wakeword"
+)
+
+// Route is an API endpoint. Each API module lists its routes, and
+// RegisterRoutes wires all of them, so every handler in the table is served
+// and shows up in the /api/routes index.
+type Route struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Feature     string `json:"feature,omitempty"` // Empty for routes that are always available
+	Description string `json:"description"`
+
+	Handler http.HandlerFunc `json:"-"`
+}
+
+// RouteInfo is an entry of the /api/routes index
+type RouteInfo struct {
+	Route
+	Enabled bool `json:"enabled"`
+}
+
+// Routes returns every API route
+func (h *Handler) Routes() []Route {
+	var routes []Route
+	for _, module := range [][]Route{
+		h.coreRoutes(),
+		h.sttRoutes(),
+		h.ttsRoutes(),
+		h.embeddingsRoutes(),
+		h.collectionRoutes(),
+		h.wakeWordRoutes(),
+		h.modelRoutes(),
+	} {
+		routes = append(routes, module...)
+	}
+	return routes
+}
+
+// RegisterRoutes registers every API route on the router. Routes of disabled
+// features are registered too; their handlers report the feature as disabled.
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	seen := make(map[string]bool)
+	for _, route := range h.Routes() {
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			log.Printf("Warning: Duplicate route %s, ignoring", key)
+			continue
+		}
+		seen[key] = true
+
+		router.HandleFunc(route.Path, route.Handler).Methods(route.Method)
+	}
+}
+
+// ListRoutes returns the index of API routes and whether their feature is enabled
+func (h *Handler) ListRoutes(w http.ResponseWriter, r *http.Request) {
+	routes := h.Routes()
+	index := make([]RouteInfo, len(routes))
+	for i, route := range routes {
+		index[i] = RouteInfo{Route: route, Enabled: h.featureEnabled(route.Feature)}
+	}
+
+	h.writeSuccess(w, map[string]interface{}{
+		"routes": index,
+	})
+}
+
+// featureEnabled reports whether a feature flag is on; routes without a
+// feature are always enabled
+func (h *Handler) featureEnabled(feature string) bool {
+	switch feature {
+	case FeatureSTT:
+		return h.config.Features.STT
+	case FeatureTTS:
+		return h.config.Features.TTS
+	case FeatureEmbeddings:
+		return h.config.Features.Embeddings
+	case FeatureWakeWord:
+		return h.config.Features.WakeWord
+	}
+	return true
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
-	"github.com/gorilla/mux"
+	"alice-backend/internal/whisper"
 )
 
 // TranscribeRequest represents a transcription request (JSON format)
@@ -13,16 +18,24 @@ type TranscribeRequest struct {
 	AudioData  []float32 `json:"audio_data,omitempty"`
 	SampleRate int       `json:"sample_rate,omitempty"`
 	Language   string    `json:"language,omitempty"`
+
+	// Model selects an installed Whisper model for this request only
+	Model string `json:"model,omitempty"`
+
+	// ResponseFormat is one of json, verbose_json, text, srt or vtt
+	ResponseFormat string `json:"response_format,omitempty"`
 }
 
 // TranscribeResponse represents a transcription response
 type TranscribeResponse struct {
-	Text       string  `json:"text"`
-	Confidence float32 `json:"confidence"`
-	Duration   float32 `json:"duration,omitempty"`
+	Text       string            `json:"text"`
+	Confidence float64           `json:"confidence"`
+	Duration   float64           `json:"duration,omitempty"`
+	Language   string            `json:"language,omitempty"`
+	Segments   []whisper.Segment `json:"segments,omitempty"`
 }
 
-// TranscribeAudio handles audio transcription (supports both multipart and JSON)
+// TranscribeAudio handles audio transcription (supports multipart, JSON and raw PCM)
 func (h *Handler) TranscribeAudio(w http.ResponseWriter, r *http.Request) {
 	if !h.config.Features.STT {
 		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
@@ -35,51 +48,171 @@ func (h *Handler) TranscribeAudio(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	audio, ok := h.readAudioRequest(w, r)
+	if !ok {
+		return
+	}
+
+	switch audio.responseFormat {
+	case "", "json", "verbose_json", "text", "srt", "vtt":
+	default:
+		h.writeError(w, http.StatusBadRequest, "response_format must be one of json, verbose_json, text, srt or vtt")
+		return
+	}
+
+	// Transcribe audio with language parameter
+	result, err := sttService.TranscribeDetailedWithModel(r.Context(), audio.audioData, audio.language, audio.model)
+	if errors.Is(err, whisper.ErrUnknownModel) || errors.Is(err, whisper.ErrModelNotInstalled) {
+		h.writeSTTModelError(w, err)
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Transcription failed: "+err.Error())
+		return
+	}
+
+	h.writeTranscription(w, result, audio.responseFormat)
+}
+
+// VADResponse lists the speech detected in an audio clip
+type VADResponse struct {
+	Segments       []whisper.SpeechSegment `json:"segments"`
+	Duration       float64                 `json:"duration"`        // Audio length in seconds
+	SpeechDuration float64                 `json:"speech_duration"` // Total length of the segments
+	Threshold      float64                 `json:"threshold"`
+}
+
+// DetectVoiceActivity returns the speech segments in an audio clip without
+// transcribing it. It accepts the same request formats as TranscribeAudio;
+// the optional threshold query parameter overrides the RMS voice threshold.
+func (h *Handler) DetectVoiceActivity(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.STT {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
+		return
+	}
+
+	// Detection runs locally, so the whisper model doesn't need to be loaded
+	sttService := h.modelManager.GetSTTService()
+	if sttService == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is not ready")
+		return
+	}
+
+	threshold := sttService.VoiceThreshold()
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			h.writeError(w, http.StatusBadRequest, "threshold must be a number in (0, 1]")
+			return
+		}
+		threshold = parsed
+	}
+
+	audio, ok := h.readAudioRequest(w, r)
+	if !ok {
+		return
+	}
+
+	samples, err := whisper.DecodePCM16(audio.audioData)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid audio data: "+err.Error())
+		return
+	}
+
+	response := VADResponse{
+		Segments:  whisper.DetectSpeech(samples, threshold),
+		Duration:  float64(len(samples)) / 16000,
+		Threshold: threshold,
+	}
+	if response.Segments == nil {
+		response.Segments = []whisper.SpeechSegment{}
+	}
+	for _, seg := range response.Segments {
+		response.SpeechDuration += seg.End - seg.Start
+	}
+
+	h.writeSuccess(w, response)
+}
+
+// audioRequest is the audio and options read from a transcription-style request
+type audioRequest struct {
+	audioData      []byte // 16kHz mono PCM16
+	language       string
+	model          string
+	responseFormat string
+}
+
+// readAudioRequest reads audio from a multipart upload, a JSON float array or a
+// raw PCM body. On failure it writes the error response and returns false.
+func (h *Handler) readAudioRequest(w http.ResponseWriter, r *http.Request) (*audioRequest, bool) {
 	var audioData []byte
 	var language string
-	var err error
+
+	// The format and model may also be given as query parameters, e.g. ?response_format=srt
+	responseFormat := r.URL.Query().Get("response_format")
+	model := r.URL.Query().Get("model")
 
 	// Check Content-Type to determine request format
 	contentType := r.Header.Get("Content-Type")
 	
-	if contentType == "application/json" {
+	if strings.HasPrefix(contentType, "application/octet-stream") {
+		// Handle raw PCM (compact alternative to the JSON float array)
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRawAudioBytes))
+		if err != nil {
+			h.writeError(w, http.StatusRequestEntityTooLarge, "Audio body is too large or could not be read")
+			return nil, false
+		}
+		if len(body) == 0 {
+			h.writeError(w, http.StatusBadRequest, "Audio data is required")
+			return nil, false
+		}
+
+		audioData, err = rawPCMToPCM16(body, r.Header.Get("X-Encoding"), r.Header.Get("X-Sample-Rate"))
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return nil, false
+		}
+
+		language = r.URL.Query().Get("language")
+	} else if contentType == "application/json" {
 		// Handle JSON request (from frontend audio processing)
 		var req TranscribeRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			h.writeError(w, http.StatusBadRequest, "Invalid JSON request body")
-			return
+			return nil, false
 		}
 
 		if len(req.AudioData) == 0 {
 			h.writeError(w, http.StatusBadRequest, "Audio data is required")
-			return
+			return nil, false
 		}
 
 		// Store language from request
 		language = req.Language
+		if req.ResponseFormat != "" {
+			responseFormat = req.ResponseFormat
+		}
+		if req.Model != "" {
+			model = req.Model
+		}
 
-		// Convert Float32Array to 16-bit PCM bytes
-		audioData = make([]byte, len(req.AudioData)*2)
-		for i, sample := range req.AudioData {
-			// Clamp sample to [-1, 1] range
-			if sample > 1.0 {
-				sample = 1.0
-			} else if sample < -1.0 {
-				sample = -1.0
+		// Browsers often capture at 44.1 or 48kHz; whisper expects 16kHz
+		samples := req.AudioData
+		if req.SampleRate != 0 {
+			if req.SampleRate < 4000 || req.SampleRate > 384000 {
+				h.writeError(w, http.StatusBadRequest, "sample_rate must be between 4000 and 384000")
+				return nil, false
 			}
-			
-			// Convert float32 to 16-bit signed integer
-			sample16 := int16(sample * 32767)
-			
-			// Write as little-endian 16-bit
-			audioData[i*2] = byte(sample16 & 0xFF)
-			audioData[i*2+1] = byte((sample16 >> 8) & 0xFF)
+			samples = whisper.Resample(samples, req.SampleRate, 16000)
 		}
+
+		// Convert Float32Array to 16-bit PCM bytes
+		audioData = whisper.EncodePCM16(samples)
 	} else {
 		// Handle multipart form (file upload)
 		if err := r.ParseMultipartForm(10 << 20); err != nil {
 			h.writeError(w, http.StatusBadRequest, "Failed to parse multipart form")
-			return
+			return nil, false
 		}
 
 		file, _, err := r.FormFile("file")
@@ -88,38 +221,141 @@ func (h *Handler) TranscribeAudio(w http.ResponseWriter, r *http.Request) {
 			file, _, err = r.FormFile("audio")
 			if err != nil {
 				h.writeError(w, http.StatusBadRequest, "Failed to get audio file (expected 'file' or 'audio' field)")
-				return
+				return nil, false
 			}
 		}
 		defer file.Close()
 
 		// Get language from form parameter
 		language = r.FormValue("language")
+		if format := r.FormValue("response_format"); format != "" {
+			responseFormat = format
+		}
+		if value := r.FormValue("model"); value != "" {
+			model = value
+		}
 
 		// Read audio data
 		audioData, err = io.ReadAll(file)
 		if err != nil {
 			h.writeError(w, http.StatusInternalServerError, "Failed to read audio file")
-			return
+			return nil, false
+		}
+
+		// Decode WAV/FLAC/Ogg uploads to 16kHz mono; anything else is taken to
+		// be raw 16-bit PCM as before
+		if format := whisper.DetectAudioFormat(audioData); format != "" {
+			samples, err := whisper.DecodeAudio(audioData)
+			if errors.Is(err, whisper.ErrUnsupportedAudio) {
+				h.writeError(w, http.StatusUnsupportedMediaType, err.Error())
+				return nil, false
+			}
+			if err != nil {
+				h.writeError(w, http.StatusBadRequest, "Failed to decode "+format+" audio: "+err.Error())
+				return nil, false
+			}
+			audioData = whisper.EncodePCM16(samples)
 		}
 	}
 
-	// Transcribe audio with language parameter
-	text, err := sttService.TranscribeAudioWithLanguage(r.Context(), audioData, language)
+	return &audioRequest{
+		audioData:      audioData,
+		language:       language,
+		model:          model,
+		responseFormat: responseFormat,
+	}, true
+}
+
+// writeTranscription writes a transcription in the requested response format.
+// Without a format the verbose JSON response is returned.
+func (h *Handler) writeTranscription(w http.ResponseWriter, result *whisper.Transcription, format string) {
+	switch format {
+	case "text":
+		h.writeBinary(w, []byte(result.Text+"\n"), "text/plain; charset=utf-8")
+	case "srt":
+		h.writeBinary(w, []byte(result.SRT()), "application/x-subrip; charset=utf-8")
+	case "vtt":
+		h.writeBinary(w, []byte(result.VTT()), "text/vtt; charset=utf-8")
+	case "json":
+		h.writeSuccess(w, TranscribeResponse{
+			Text:       result.Text,
+			Confidence: result.Confidence,
+			Duration:   result.Duration,
+			Language:   result.Language,
+		})
+	default:
+		h.writeSuccess(w, TranscribeResponse{
+			Text:       result.Text,
+			Confidence: result.Confidence,
+			Duration:   result.Duration,
+			Language:   result.Language,
+			Segments:   result.Segments,
+		})
+	}
+}
+
+// maxRawAudioBytes limits raw PCM uploads (about 10 minutes of 16kHz f32le audio)
+const maxRawAudioBytes = 40 << 20
+
+// errRawEncoding is returned by decodeRawPCM for an unknown sample encoding
+var errRawEncoding = errors.New("encoding must be \"f32le\" or \"s16le\"")
+
+// rawPCMToPCM16 converts a raw PCM body described by the X-Encoding ("f32le",
+// the default, or "s16le") and X-Sample-Rate (default 16000) headers to 16kHz PCM16
+func rawPCMToPCM16(body []byte, encoding, sampleRateHeader string) ([]byte, error) {
+	sampleRate := 16000
+	if sampleRateHeader != "" {
+		rate, err := strconv.Atoi(sampleRateHeader)
+		if err != nil || rate < 4000 || rate > 384000 {
+			return nil, errors.New("X-Sample-Rate must be between 4000 and 384000")
+		}
+		sampleRate = rate
+	}
+
+	samples, err := decodeRawPCM(body, encoding)
+	if errors.Is(err, errRawEncoding) {
+		return nil, errors.New("X-Encoding must be \"f32le\" or \"s16le\"")
+	}
 	if err != nil {
-		h.writeError(w, http.StatusInternalServerError, "Transcription failed: "+err.Error())
-		return
+		return nil, err
 	}
+	return whisper.EncodePCM16(whisper.Resample(samples, sampleRate, 16000)), nil
+}
 
-	h.writeSuccess(w, TranscribeResponse{
-		Text:       text,
-		Confidence: 0.95, // Placeholder confidence
-		Duration:   1.0,  // Placeholder duration
-	})
+// decodeRawPCM converts little-endian mono PCM in the given encoding ("f32le",
+// the default, or "s16le") to float32 samples
+func decodeRawPCM(body []byte, encoding string) ([]float32, error) {
+	switch strings.ToLower(encoding) {
+	case "", "f32le":
+		if len(body)%4 != 0 {
+			return nil, errors.New("f32le audio length must be a multiple of 4 bytes")
+		}
+		samples := make([]float32, len(body)/4)
+		for i := range samples {
+			samples[i] = math.Float32frombits(binary.LittleEndian.Uint32(body[i*4:]))
+		}
+		return samples, nil
+	case "s16le":
+		if len(body)%2 != 0 {
+			return nil, errors.New("s16le audio length must be a multiple of 2 bytes")
+		}
+		return whisper.DecodePCM16(body)
+	}
+	return nil, errRawEncoding
 }
 
-// RegisterSTTRoutes registers STT-related routes
-func (h *Handler) RegisterSTTRoutes(router *mux.Router) {
-	sttRouter := router.PathPrefix("/api/stt").Subrouter()
-	sttRouter.HandleFunc("/transcribe", h.TranscribeAudio).Methods("POST")
+// sttRoutes lists the speech-to-text routes
+func (h *Handler) sttRoutes() []Route {
+	return []Route{
+		{"POST", "/api/stt/transcribe", FeatureSTT, "Transcribe audio from a multipart upload, a JSON float array or raw PCM", h.TranscribeAudio},
+		{"POST", "/api/stt/transcribe-audio", FeatureSTT, "Alias of /api/stt/transcribe", h.TranscribeAudio},
+		{"POST", "/api/stt/transcribe-file", FeatureSTT, "Alias of /api/stt/transcribe", h.TranscribeAudio},
+		{"GET", "/api/stt/ready", FeatureSTT, "Report whether the STT service is ready", h.STTReady},
+		{"GET", "/api/stt/info", FeatureSTT, "STT service information", h.STTInfo},
+		{"GET", "/api/stt/stream", FeatureSTT, "Real-time transcription over a WebSocket", h.TranscribeStream},
+		{"POST", "/api/stt/vad", FeatureSTT, "Detect speech segments in audio", h.DetectVoiceActivity},
+		{"GET", "/api/stt/models", FeatureSTT, "List Whisper models and which are installed", h.GetSTTModels},
+		{"POST", "/api/stt/models/active", FeatureSTT, "Switch the active Whisper model", h.SetSTTModel},
+		{"POST", "/api/stt/models/{name}/download", FeatureSTT, "Download a Whisper model in the background", h.DownloadSTTModel},
+	}
 }
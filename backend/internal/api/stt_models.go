@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"alice-backend/internal/models"
+	"alice-backend/internal/whisper"
+
+	"github.com/gorilla/mux"
+)
+
+// SetSTTModelRequest switches the active Whisper model
+type SetSTTModelRequest struct {
+	Model string `json:"model"`
+}
+
+// STTModelsResponse lists the Whisper models and the active one
+type STTModelsResponse struct {
+	Models []whisper.ModelInfo `json:"models"`
+	Active string              `json:"active"`
+}
+
+// GetSTTModels lists the Whisper models of the catalog and which are installed
+func (h *Handler) GetSTTModels(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.STT {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
+		return
+	}
+
+	sttService := h.modelManager.GetSTTService()
+	if sttService == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service not available")
+		return
+	}
+
+	h.writeSuccess(w, STTModelsResponse{
+		Models: sttService.ListModels(),
+		Active: sttService.ActiveModel(),
+	})
+}
+
+// DownloadSTTModel starts downloading a Whisper model in the background; its
+// progress is reported like any other model download
+func (h *Handler) DownloadSTTModel(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.STT {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
+		return
+	}
+
+	h.startDownload(w, models.ServiceSTT, mux.Vars(r)["name"])
+}
+
+// SetSTTModel switches transcription to another installed Whisper model
+func (h *Handler) SetSTTModel(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.STT {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
+		return
+	}
+
+	sttService := h.modelManager.GetSTTService()
+	if sttService == nil || !sttService.IsReady() {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is not ready")
+		return
+	}
+
+	var req SetSTTModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Model == "" {
+		h.writeError(w, http.StatusBadRequest, "Model is required")
+		return
+	}
+
+	if err := sttService.SetModel(req.Model); err != nil {
+		h.writeSTTModelError(w, err)
+		return
+	}
+
+	h.writeSuccess(w, STTModelsResponse{
+		Models: sttService.ListModels(),
+		Active: sttService.ActiveModel(),
+	})
+}
+
+// writeSTTModelError maps model selection errors to status codes
+func (h *Handler) writeSTTModelError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, whisper.ErrUnknownModel):
+		h.writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, whisper.ErrModelNotInstalled):
+		h.writeError(w, http.StatusConflict, err.Error()+"; download it first")
+	default:
+		h.writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
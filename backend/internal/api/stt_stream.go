@@ -0,0 +1,326 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"alice-backend/internal/wakeword"
+	"alice-backend/internal/whisper"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// streamIdleTimeout closes streaming sessions that stop sending audio
+	streamIdleTimeout = 60 * time.Second
+
+	// streamWriteTimeout bounds how long a slow client can stall an event
+	streamWriteTimeout = 10 * time.Second
+
+	// wakeListenTimeout is how long a wake-gated stream waits for speech after
+	// the wake word before going back to waiting for it
+	wakeListenTimeout = 5 * 16000
+)
+
+// streamMessage is a message received from a streaming client; binary frames
+// carry audio and text frames carry JSON control messages
+type streamMessage struct {
+	binary bool
+	data   []byte
+}
+
+// streamControl is a JSON control message, e.g. {"type":"end"}
+type streamControl struct {
+	Type string `json:"type"`
+}
+
+// wakeWordEvent is sent on a stream when the wake word is detected
+type wakeWordEvent struct {
+	Type string `json:"type"`
+	wakeword.Detection
+}
+
+// streamCodec receives raw frames with their type and sends JSON text frames
+var streamCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		data, err := json.Marshal(v)
+		return data, websocket.TextFrame, err
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		msg, ok := v.(*streamMessage)
+		if !ok {
+			return errors.New("unexpected stream message type")
+		}
+		msg.binary = payloadType == websocket.BinaryFrame
+		msg.data = data
+		return nil
+	},
+}
+
+// streamAudioParams are the audio format query parameters of streaming endpoints
+type streamAudioParams struct {
+	sampleRate int
+	encoding   string
+}
+
+// parseStreamAudioParams reads sample_rate (default 16000) and encoding
+// ("f32le", the default, or "s16le") from the query
+func parseStreamAudioParams(query url.Values) (streamAudioParams, error) {
+	params := streamAudioParams{sampleRate: 16000, encoding: query.Get("encoding")}
+	if value := query.Get("sample_rate"); value != "" {
+		rate, err := strconv.Atoi(value)
+		if err != nil || rate < 4000 || rate > 384000 {
+			return params, errors.New("sample_rate must be between 4000 and 384000")
+		}
+		params.sampleRate = rate
+	}
+
+	if _, err := decodeRawPCM(nil, params.encoding); err != nil {
+		return params, err
+	}
+	return params, nil
+}
+
+// parseSensitivity reads an optional wake-word sensitivity; zero means the default
+func parseSensitivity(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	sensitivity, err := strconv.ParseFloat(value, 64)
+	if err != nil || sensitivity <= 0 || sensitivity >= 1 {
+		return 0, errors.New("sensitivity must be between 0 and 1 (exclusive)")
+	}
+	return sensitivity, nil
+}
+
+// audioStream is a WebSocket carrying PCM audio from the client and JSON
+// events back to it
+type audioStream struct {
+	ws        *websocket.Conn
+	ctx       context.Context
+	cancel    context.CancelFunc
+	encoding  string
+	resampler *whisper.StreamResampler
+
+	writeMu sync.Mutex
+}
+
+func newAudioStream(ws *websocket.Conn, params streamAudioParams) *audioStream {
+	// The connection outlives the server's read and write timeouts
+	ws.SetDeadline(time.Time{})
+
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	return &audioStream{
+		ws:        ws,
+		ctx:       ctx,
+		cancel:    cancel,
+		encoding:  params.encoding,
+		resampler: whisper.NewStreamResampler(params.sampleRate, 16000),
+	}
+}
+
+// send writes a JSON event; it is safe for concurrent use. A failed write
+// cancels the stream's context.
+func (s *audioStream) send(v interface{}) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err := streamCodec.Send(s.ws, v); err != nil {
+		s.cancel()
+	}
+}
+
+func (s *audioStream) sendError(message string) {
+	s.send(whisper.StreamEvent{Type: whisper.StreamEventError, Error: message})
+}
+
+// next returns the next chunk of audio at 16kHz, or end when the client sent
+// {"type":"end"}. Invalid messages are reported to the client and skipped.
+// An error means the client disconnected, went idle or stopped reading.
+func (s *audioStream) next() (samples []float32, end bool, err error) {
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		s.ws.SetReadDeadline(time.Now().Add(streamIdleTimeout))
+		var msg streamMessage
+		if err := streamCodec.Receive(s.ws, &msg); err != nil {
+			return nil, false, err
+		}
+
+		if !msg.binary {
+			var control streamControl
+			if err := json.Unmarshal(msg.data, &control); err != nil || control.Type != "end" {
+				s.sendError(`Unknown control message; expected {"type":"end"}`)
+				continue
+			}
+			return nil, true, nil
+		}
+
+		samples, err := decodeRawPCM(msg.data, s.encoding)
+		if err != nil {
+			s.sendError(err.Error())
+			continue
+		}
+		return s.resampler.Process(samples), false, nil
+	}
+}
+
+// TranscribeStream handles real-time transcription over a WebSocket.
+//
+// Query parameters: sample_rate (default 16000), encoding ("f32le", the
+// default, or "s16le") and language. Binary messages carry mono PCM audio;
+// the text message {"type":"end"} flushes the last utterance and closes the
+// session. The server sends JSON events: ready, speech_start, partial, final,
+// error and end.
+//
+// With wake_word=true only speech following the wake word is transcribed: a
+// wake_word event is sent on detection, then the next utterance is
+// transcribed. sensitivity overrides the wake-word sensitivity.
+func (h *Handler) TranscribeStream(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.STT {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is disabled")
+		return
+	}
+
+	sttService := h.modelManager.GetSTTService()
+	if sttService == nil || !sttService.IsReady() {
+		h.writeError(w, http.StatusServiceUnavailable, "STT service is not ready")
+		return
+	}
+
+	query := r.URL.Query()
+	params, err := parseStreamAudioParams(query)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var wakeWordService *wakeword.Service
+	var sensitivity float64
+	if query.Get("wake_word") == "true" {
+		if wakeWordService = h.readyWakeWordService(w); wakeWordService == nil {
+			return
+		}
+		if sensitivity, err = parseSensitivity(query.Get("sensitivity")); err != nil {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	server := websocket.Server{
+		Handshake: checkStreamOrigin,
+		Handler: func(ws *websocket.Conn) {
+			stream := newAudioStream(ws, params)
+			defer stream.cancel()
+
+			var detector *wakeword.Detector
+			listening := &atomic.Bool{}
+			if wakeWordService != nil {
+				var detectorErr error
+				detector, detectorErr = wakeWordService.NewDetector(sensitivity, func(detection wakeword.Detection) {
+					listening.Store(true)
+					stream.send(wakeWordEvent{Type: "wake_word", Detection: detection})
+				})
+				if detectorErr != nil {
+					stream.sendError(detectorErr.Error())
+					return
+				}
+			}
+
+			stream.send(map[string]interface{}{
+				"type":        "ready",
+				"sample_rate": params.sampleRate,
+				"wake_word":   detector != nil,
+			})
+			runTranscribeStream(stream, sttService, query.Get("language"), detector, listening)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// runTranscribeStream feeds audio from the stream into a transcription
+// session until the client ends the stream, disconnects or goes idle. With a
+// detector, audio only reaches the session while listening is set.
+func runTranscribeStream(stream *audioStream, sttService *whisper.STTService, language string, detector *wakeword.Detector, listening *atomic.Bool) {
+	var heardSpeech atomic.Bool
+	session := sttService.NewStream(stream.ctx, language, func(event whisper.StreamEvent) {
+		switch event.Type {
+		case whisper.StreamEventSpeechStart:
+			heardSpeech.Store(true)
+		case whisper.StreamEventFinal:
+			// A wake-gated stream goes back to waiting after each utterance
+			listening.Store(false)
+			heardSpeech.Store(false)
+		}
+		stream.send(event)
+	})
+
+	sinceWake := 0
+	for {
+		samples, end, err := stream.next()
+		if err != nil {
+			if stream.ctx.Err() == nil {
+				log.Printf("[STTStream] Closing stream: %v", err)
+			}
+			// Nobody is left to read the results
+			session.Abort()
+			return
+		}
+		if end {
+			session.Close()
+			stream.send(map[string]interface{}{"type": "end"})
+			return
+		}
+
+		if detector == nil {
+			session.Write(samples)
+			continue
+		}
+
+		if err := detector.Write(samples); err != nil {
+			stream.sendError("Wake-word detection failed: " + err.Error())
+		}
+		if !listening.Load() {
+			session.Skip(len(samples))
+			sinceWake = 0
+			continue
+		}
+
+		session.Write(samples)
+		sinceWake += len(samples)
+		if sinceWake > wakeListenTimeout && !heardSpeech.Load() {
+			listening.Store(false)
+		}
+	}
+}
+
+// checkStreamOrigin accepts clients without an Origin header (non-browser
+// clients) and browser pages served from localhost, matching the CORS policy
+func checkStreamOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	switch parsed.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		config.Origin = parsed
+		return nil
+	}
+	return errors.New("origin not allowed: " + origin)
+}
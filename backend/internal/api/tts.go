@@ -1,10 +1,15 @@
 package api
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
-	"github.com/gorilla/mux"
+	"alice-backend/internal/piper"
 )
 
 // SynthesizeRequest represents a TTS synthesis request
@@ -12,14 +17,47 @@ type SynthesizeRequest struct {
 	Text  string  `json:"text"`
 	Voice string  `json:"voice,omitempty"`
 	Speed float32 `json:"speed,omitempty"`
+
+	// Speaker selects a speaker of a multi-speaker voice by name; SpeakerID by numeric id
+	Speaker   string `json:"speaker,omitempty"`
+	SpeakerID *int   `json:"speaker_id,omitempty"`
+
+	// Piper inference settings; omitted values use the voice's defaults
+	LengthScale     *float32 `json:"length_scale,omitempty"`
+	NoiseScale      *float32 `json:"noise_scale,omitempty"`
+	NoiseW          *float32 `json:"noise_w,omitempty"`
+	SentenceSilence *float32 `json:"sentence_silence,omitempty"`
+
+	// AudioEncoding selects how JSON responses carry the audio: "array" (default) or "base64"
+	AudioEncoding string `json:"audio_encoding,omitempty"`
+}
+
+// synthesisOptions converts the request's tuning fields into service options
+func (req *SynthesizeRequest) synthesisOptions() (piper.SynthesisOptions, error) {
+	opts := piper.SynthesisOptions{
+		Speaker:         req.Speaker,
+		Speed:           req.Speed,
+		LengthScale:     req.LengthScale,
+		NoiseScale:      req.NoiseScale,
+		NoiseW:          req.NoiseW,
+		SentenceSilence: req.SentenceSilence,
+	}
+	if req.SpeakerID != nil {
+		if req.Speaker != "" {
+			return opts, errors.New("Specify either speaker or speaker_id, not both")
+		}
+		opts.SpeakerID = req.SpeakerID
+	}
+	return opts, nil
 }
 
 // VoiceResponse represents a voice information response
 type VoiceResponse struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Language    string `json:"language"`
-	Gender      string `json:"gender"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Language    string   `json:"language"`
+	Gender      string   `json:"gender"`
+	Speakers    []string `json:"speakers,omitempty"`
 }
 
 // SynthesizeSpeech handles TTS synthesis
@@ -50,28 +88,80 @@ func (h *Handler) SynthesizeSpeech(w http.ResponseWriter, r *http.Request) {
 		req.Voice = "en-US-amy-medium"
 	}
 
-	audioData, err := ttsService.Synthesize(r.Context(), req.Text, req.Voice)
+	if req.AudioEncoding != "" && req.AudioEncoding != "array" && req.AudioEncoding != "base64" {
+		h.writeError(w, http.StatusBadRequest, "audio_encoding must be \"array\" or \"base64\"")
+		return
+	}
+
+	opts, err := req.synthesisOptions()
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	audioData, err := ttsService.SynthesizeWithOptions(r.Context(), req.Text, req.Voice, opts)
+	if errors.Is(err, piper.ErrInvalidOptions) {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "TTS synthesis failed: "+err.Error())
 		return
 	}
 
-	// Convert byte array to number array for frontend compatibility
-	audioNumbers := make([]int, len(audioData))
-	for i, b := range audioData {
-		audioNumbers[i] = int(b)
+	sampleRate := 22050
+	duration := 0.0
+	if wavInfo, err := piper.ParseWAVInfo(audioData); err == nil {
+		sampleRate = wavInfo.SampleRate
+		duration = wavInfo.Duration()
+	} else {
+		log.Printf("Could not read WAV header of synthesized audio: %v", err)
+	}
+
+	if acceptsWAV(r) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(audioData)))
+		w.Header().Set("X-Sample-Rate", strconv.Itoa(sampleRate))
+		w.Header().Set("X-Audio-Duration", strconv.FormatFloat(duration, 'f', 3, 64))
+		h.writeBinary(w, audioData, "audio/wav")
+		return
 	}
 
 	response := map[string]interface{}{
-		"audio":       audioNumbers,
 		"format":      "wav",
-		"sample_rate": 22050,
-		"duration":    1.0, // Placeholder duration
+		"sample_rate": sampleRate,
+		"duration":    duration,
+	}
+
+	switch req.AudioEncoding {
+	case "base64":
+		response["audio"] = base64.StdEncoding.EncodeToString(audioData)
+		response["encoding"] = "base64"
+	default:
+		// Convert byte array to number array for frontend compatibility
+		audioNumbers := make([]int, len(audioData))
+		for i, b := range audioData {
+			audioNumbers[i] = int(b)
+		}
+		response["audio"] = audioNumbers
 	}
 
 	h.writeSuccess(w, response)
 }
 
+// acceptsWAV reports whether the client asked for raw WAV bytes rather than JSON
+func acceptsWAV(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "audio/wav", "audio/x-wav", "audio/wave", "audio/*":
+			return true
+		case "application/json":
+			return false
+		}
+	}
+	return false
+}
+
 // GetVoices returns available TTS voices
 func (h *Handler) GetVoices(w http.ResponseWriter, r *http.Request) {
 	if !h.config.Features.TTS {
@@ -94,6 +184,7 @@ func (h *Handler) GetVoices(w http.ResponseWriter, r *http.Request) {
 			Description: voice.Description,
 			Language:    voice.Language,
 			Gender:      voice.Gender,
+			Speakers:    voice.Speakers,
 		}
 	}
 
@@ -168,11 +259,15 @@ func (h *Handler) SetDefaultVoice(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, response)
 }
 
-// RegisterTTSRoutes registers TTS-related routes
-func (h *Handler) RegisterTTSRoutes(router *mux.Router) {
-	ttsRouter := router.PathPrefix("/api/tts").Subrouter()
-	ttsRouter.HandleFunc("/synthesize", h.SynthesizeSpeech).Methods("POST")
-	ttsRouter.HandleFunc("/voices", h.GetVoices).Methods("GET")
-	ttsRouter.HandleFunc("/default-voice", h.GetDefaultVoice).Methods("GET")
-	ttsRouter.HandleFunc("/default-voice", h.SetDefaultVoice).Methods("POST")
+// ttsRoutes lists the text-to-speech routes
+func (h *Handler) ttsRoutes() []Route {
+	return []Route{
+		{"POST", "/api/tts/synthesize", FeatureTTS, "Synthesize speech from text", h.SynthesizeSpeech},
+		{"POST", "/api/tts/stream", FeatureTTS, "Synthesize speech and stream it sentence by sentence", h.SynthesizeSpeechStream},
+		{"GET", "/api/tts/voices", FeatureTTS, "List available voices", h.GetVoices},
+		{"GET", "/api/tts/default-voice", FeatureTTS, "Get the default voice", h.GetDefaultVoice},
+		{"POST", "/api/tts/default-voice", FeatureTTS, "Set the default voice", h.SetDefaultVoice},
+		{"GET", "/api/tts/ready", FeatureTTS, "Report whether the TTS service is ready", h.TTSReady},
+		{"GET", "/api/tts/info", FeatureTTS, "TTS service information", h.TTSInfo},
+	}
 }
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"alice-backend/internal/piper"
+)
+
+// StreamChunk is one line of an application/x-ndjson synthesis stream
+type StreamChunk struct {
+	Index         int    `json:"index"`
+	Text          string `json:"text,omitempty"`
+	Audio         string `json:"audio,omitempty"` // Base64-encoded PCM
+	SampleRate    int    `json:"sample_rate,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	BitsPerSample int    `json:"bits_per_sample,omitempty"`
+	Final         bool   `json:"final"`
+	Error         string `json:"error,omitempty"`
+}
+
+// SynthesizeSpeechStream synthesizes text sentence by sentence and streams each
+// sentence's audio as soon as it is ready, so playback can start early.
+//
+// By default the body is raw little-endian PCM sent with chunked transfer
+// encoding; the format is described by the X-Sample-Rate, X-Channels and
+// X-Bits-Per-Sample headers. With "Accept: application/x-ndjson" every sentence
+// is sent as a JSON line carrying its text and base64-encoded PCM.
+func (h *Handler) SynthesizeSpeechStream(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.TTS {
+		h.writeError(w, http.StatusServiceUnavailable, "TTS service is disabled")
+		return
+	}
+
+	ttsService := h.modelManager.GetTTSService()
+	if ttsService == nil || !ttsService.IsReady() {
+		h.writeError(w, http.StatusServiceUnavailable, "TTS service is not ready")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	var req SynthesizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		h.writeError(w, http.StatusBadRequest, "Text is required")
+		return
+	}
+
+	opts, err := req.synthesisOptions()
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := opts.Validate(); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Long replies can take longer than the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to configure audio stream")
+		return
+	}
+
+	ndjson := strings.Contains(r.Header.Get("Accept"), "application/x-ndjson")
+	started := false
+
+	err = ttsService.SynthesizeStream(r.Context(), req.Text, req.Voice, opts, func(chunk piper.AudioChunk) error {
+		if !started {
+			// Headers go out with the first sentence, once the audio format is known
+			if ndjson {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			} else {
+				w.Header().Set("Content-Type", "application/octet-stream")
+				w.Header().Set("X-Sample-Rate", strconv.Itoa(chunk.SampleRate))
+				w.Header().Set("X-Channels", strconv.Itoa(chunk.Channels))
+				w.Header().Set("X-Bits-Per-Sample", strconv.Itoa(chunk.BitsPerSample))
+			}
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		if ndjson {
+			if err := json.NewEncoder(w).Encode(StreamChunk{
+				Index:         chunk.Index,
+				Text:          chunk.Text,
+				Audio:         base64.StdEncoding.EncodeToString(chunk.PCM),
+				SampleRate:    chunk.SampleRate,
+				Channels:      chunk.Channels,
+				BitsPerSample: chunk.BitsPerSample,
+				Final:         chunk.Final,
+			}); err != nil {
+				return err
+			}
+		} else if _, err := w.Write(chunk.PCM); err != nil {
+			return err
+		}
+
+		flusher.Flush()
+		return nil
+	})
+
+	if err == nil {
+		return
+	}
+
+	if !started {
+		status := http.StatusInternalServerError
+		if errors.Is(err, piper.ErrInvalidOptions) {
+			status = http.StatusBadRequest
+		}
+		h.writeError(w, status, "TTS synthesis failed: "+err.Error())
+		return
+	}
+
+	// The status line is already sent; report the failure in-band where possible
+	log.Printf("TTS stream aborted: %v", err)
+	if ndjson && r.Context().Err() == nil {
+		json.NewEncoder(w).Encode(StreamChunk{Final: true, Error: err.Error()})
+		flusher.Flush()
+	}
+}
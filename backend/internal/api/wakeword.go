@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"alice-backend/internal/wakeword"
+
+	"golang.org/x/net/websocket"
+)
+
+// WakeWordSensitivityRequest changes the default wake-word sensitivity
+type WakeWordSensitivityRequest struct {
+	Sensitivity float64 `json:"sensitivity"`
+}
+
+// readyWakeWordService returns the wake-word service, or writes an error
+// response and returns nil if it is disabled or not ready
+func (h *Handler) readyWakeWordService(w http.ResponseWriter) *wakeword.Service {
+	if !h.config.Features.WakeWord {
+		h.writeError(w, http.StatusServiceUnavailable, "Wake-word service is disabled")
+		return nil
+	}
+
+	service := h.modelManager.GetWakeWordService()
+	if service == nil || !service.IsReady() {
+		h.writeError(w, http.StatusServiceUnavailable, "Wake-word service is not ready")
+		return nil
+	}
+	return service
+}
+
+// WakeWordInfo returns wake-word service information
+func (h *Handler) WakeWordInfo(w http.ResponseWriter, r *http.Request) {
+	if !h.config.Features.WakeWord {
+		h.writeError(w, http.StatusServiceUnavailable, "Wake-word service is disabled")
+		return
+	}
+
+	service := h.modelManager.GetWakeWordService()
+	if service == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Wake-word service is not initialized")
+		return
+	}
+
+	h.writeSuccess(w, service.GetInfo())
+}
+
+// SetWakeWordSensitivity changes the sensitivity used by streams that don't
+// set their own
+func (h *Handler) SetWakeWordSensitivity(w http.ResponseWriter, r *http.Request) {
+	service := h.readyWakeWordService(w)
+	if service == nil {
+		return
+	}
+
+	var req WakeWordSensitivityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if err := service.SetSensitivity(req.Sensitivity); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.writeSuccess(w, service.GetInfo())
+}
+
+// WakeWordEvents streams the detections of every wake-word stream as
+// Server-Sent Events, so other parts of the app can react to activation
+func (h *Handler) WakeWordEvents(w http.ResponseWriter, r *http.Request) {
+	service := h.readyWakeWordService(w)
+	if service == nil {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to configure event stream")
+		return
+	}
+
+	eventCh, unsubscribe := service.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// WakeWordStream listens for the wake word on a WebSocket audio stream.
+//
+// It takes the same audio as /api/stt/stream (sample_rate and encoding query
+// parameters, binary PCM messages, {"type":"end"} to finish) plus an optional
+// sensitivity, and sends ready, wake_word, error and end events.
+func (h *Handler) WakeWordStream(w http.ResponseWriter, r *http.Request) {
+	service := h.readyWakeWordService(w)
+	if service == nil {
+		return
+	}
+
+	query := r.URL.Query()
+	params, err := parseStreamAudioParams(query)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sensitivity, err := parseSensitivity(query.Get("sensitivity"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkStreamOrigin,
+		Handler: func(ws *websocket.Conn) {
+			stream := newAudioStream(ws, params)
+			defer stream.cancel()
+
+			detector, err := service.NewDetector(sensitivity, func(detection wakeword.Detection) {
+				stream.send(wakeWordEvent{Type: "wake_word", Detection: detection})
+			})
+			if err != nil {
+				stream.sendError(err.Error())
+				return
+			}
+
+			stream.send(map[string]interface{}{
+				"type":        "ready",
+				"sample_rate": params.sampleRate,
+			})
+
+			for {
+				samples, end, err := stream.next()
+				if err != nil {
+					if stream.ctx.Err() == nil {
+						log.Printf("[WakeWord] Closing stream: %v", err)
+					}
+					return
+				}
+				if end {
+					stream.send(map[string]interface{}{"type": "end"})
+					return
+				}
+
+				if err := detector.Write(samples); err != nil {
+					stream.sendError("Wake-word detection failed: " + err.Error())
+					return
+				}
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// wakeWordRoutes lists the wake-word routes
+func (h *Handler) wakeWordRoutes() []Route {
+	return []Route{
+		{"GET", "/api/wakeword/info", FeatureWakeWord, "Wake-word service information", h.WakeWordInfo},
+		{"POST", "/api/wakeword/sensitivity", FeatureWakeWord, "Set the default wake-word sensitivity", h.SetWakeWordSensitivity},
+		{"GET", "/api/wakeword/events", FeatureWakeWord, "Wake-word detections as Server-Sent Events", h.WakeWordEvents},
+		{"GET", "/api/wakeword/stream", FeatureWakeWord, "Listen for the wake word on a WebSocket audio stream", h.WakeWordStream},
+	}
+}
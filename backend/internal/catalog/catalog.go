@@ -0,0 +1,253 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"alice-backend/internal/downloader"
+)
+
+// SchemaVersion is the catalog format understood by this backend
+const SchemaVersion = 1
+
+//go:embed catalog.json
+var embeddedCatalog []byte
+
+// Catalog lists every model, voice and binary the backend knows how to download
+type Catalog struct {
+	Version  int      `json:"version"`
+	Binaries []Binary `json:"binaries"`
+	Models   []Model  `json:"models"`
+	Voices   []Voice  `json:"voices"`
+}
+
+// Artifact is a single downloadable file with its mirrors
+type Artifact struct {
+	File   string   `json:"file"`
+	URLs   []string `json:"urls"`
+	SHA256 string   `json:"sha256,omitempty"`
+	Size   int64    `json:"size,omitempty"`
+}
+
+// Binary is an executable or shared library distributed per platform.
+// Platforms are keyed by "os/arch", or just "os" to match any architecture.
+type Binary struct {
+	Name      string              `json:"name"`
+	Version   string              `json:"version,omitempty"`
+	License   string              `json:"license,omitempty"`
+	Platforms map[string]Artifact `json:"platforms"`
+}
+
+// Model is a model file set used by a service
+type Model struct {
+	Name        string     `json:"name"`
+	Service     string     `json:"service"`
+	Description string     `json:"description,omitempty"`
+	License     string     `json:"license,omitempty"`
+	Default     bool       `json:"default,omitempty"`
+	Files       []Artifact `json:"files"`
+}
+
+// Voice is a Piper voice. Bundled voices are shipped with the app as embedded assets.
+type Voice struct {
+	Name        string     `json:"name"`
+	Language    string     `json:"language"`
+	Gender      string     `json:"gender"`
+	Quality     string     `json:"quality"`
+	SampleRate  int        `json:"sample_rate"`
+	Description string     `json:"description"`
+	License     string     `json:"license,omitempty"`
+	Bundled     bool       `json:"bundled,omitempty"`
+	Files       []Artifact `json:"files,omitempty"`
+}
+
+// Parse decodes and validates a catalog
+func Parse(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid catalog: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Load reads a catalog file from disk
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Validate checks that the catalog is usable
+func (c *Catalog) Validate() error {
+	if c.Version != SchemaVersion {
+		return fmt.Errorf("unsupported catalog version %d (expected %d)", c.Version, SchemaVersion)
+	}
+
+	seen := make(map[string]bool)
+	for _, b := range c.Binaries {
+		if b.Name == "" || seen["binary/"+b.Name] {
+			return fmt.Errorf("invalid or duplicate binary name %q", b.Name)
+		}
+		seen["binary/"+b.Name] = true
+		for platform, artifact := range b.Platforms {
+			if err := artifact.validate(); err != nil {
+				return fmt.Errorf("binary %s (%s): %w", b.Name, platform, err)
+			}
+		}
+	}
+
+	defaults := make(map[string]bool)
+	for _, m := range c.Models {
+		if m.Name == "" || m.Service == "" || seen["model/"+m.Service+"/"+m.Name] {
+			return fmt.Errorf("invalid or duplicate model %q", m.Name)
+		}
+		seen["model/"+m.Service+"/"+m.Name] = true
+		if m.Default {
+			if defaults[m.Service] {
+				return fmt.Errorf("more than one default model for %s", m.Service)
+			}
+			defaults[m.Service] = true
+		}
+		if len(m.Files) == 0 {
+			return fmt.Errorf("model %s has no files", m.Name)
+		}
+		for _, artifact := range m.Files {
+			if err := artifact.validate(); err != nil {
+				return fmt.Errorf("model %s: %w", m.Name, err)
+			}
+		}
+	}
+
+	for _, v := range c.Voices {
+		if v.Name == "" || seen["voice/"+v.Name] {
+			return fmt.Errorf("invalid or duplicate voice name %q", v.Name)
+		}
+		seen["voice/"+v.Name] = true
+		for _, artifact := range v.Files {
+			if err := artifact.validate(); err != nil {
+				return fmt.Errorf("voice %s: %w", v.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a Artifact) validate() error {
+	if a.File == "" || filepath.Base(a.File) != a.File {
+		return fmt.Errorf("invalid file name %q", a.File)
+	}
+	if len(a.URLs) == 0 {
+		return fmt.Errorf("no URLs for %s", a.File)
+	}
+	if a.SHA256 != "" {
+		if sum, err := hex.DecodeString(a.SHA256); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("invalid sha256 for %s", a.File)
+		}
+	}
+	if a.Size < 0 {
+		return fmt.Errorf("invalid size for %s", a.File)
+	}
+	return nil
+}
+
+// Download returns the downloader description of the artifact stored in dir
+func (a Artifact) Download(dir string) downloader.File {
+	return downloader.File{
+		URLs:     a.URLs,
+		DestPath: filepath.Join(dir, a.File),
+		SHA256:   a.SHA256,
+		Size:     a.Size,
+	}
+}
+
+// Binary returns the binary with the given name
+func (c *Catalog) Binary(name string) (*Binary, bool) {
+	for i := range c.Binaries {
+		if c.Binaries[i].Name == name {
+			return &c.Binaries[i], true
+		}
+	}
+	return nil, false
+}
+
+// ForPlatform returns the artifact for an OS and architecture, preferring an
+// exact "os/arch" match over an OS-wide entry
+func (b *Binary) ForPlatform(goos, goarch string) (Artifact, bool) {
+	if artifact, ok := b.Platforms[goos+"/"+goarch]; ok {
+		return artifact, true
+	}
+	artifact, ok := b.Platforms[goos]
+	return artifact, ok
+}
+
+// Model returns the model of a service with the given name
+func (c *Catalog) Model(service, name string) (*Model, bool) {
+	for i := range c.Models {
+		if c.Models[i].Service == service && c.Models[i].Name == name {
+			return &c.Models[i], true
+		}
+	}
+	return nil, false
+}
+
+// ServiceModels returns the models of a service in catalog order
+func (c *Catalog) ServiceModels(service string) []*Model {
+	var models []*Model
+	for i := range c.Models {
+		if c.Models[i].Service == service {
+			models = append(models, &c.Models[i])
+		}
+	}
+	return models
+}
+
+// DefaultModel returns the model a service uses unless configured otherwise
+func (c *Catalog) DefaultModel(service string) (*Model, bool) {
+	for i := range c.Models {
+		if c.Models[i].Service == service && c.Models[i].Default {
+			return &c.Models[i], true
+		}
+	}
+	return nil, false
+}
+
+// Voice returns the voice with the given name
+func (c *Catalog) Voice(name string) (*Voice, bool) {
+	for i := range c.Voices {
+		if c.Voices[i].Name == name {
+			return &c.Voices[i], true
+		}
+	}
+	return nil, false
+}
+
+var defaultCatalog = mustParse(embeddedCatalog)
+
+// Default returns the catalog in use by the process
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// SetDefault replaces the process-wide catalog, e.g. with one loaded from disk
+func SetDefault(c *Catalog) {
+	defaultCatalog = c
+}
+
+func mustParse(data []byte) *Catalog {
+	c, err := Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("embedded model catalog: %v", err))
+	}
+	return c
+}
@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCatalogChecksums fails for every artifact in the built-in catalog that
+// can't be verified after download. Fill them in with `make catalog-checksums`.
+func TestCatalogChecksums(t *testing.T) {
+	c := Default()
+
+	check := func(name string, a Artifact) {
+		if a.SHA256 == "" || a.Size <= 0 {
+			t.Errorf("%s: %s has no sha256 or size", name, a.File)
+		}
+	}
+	for _, b := range c.Binaries {
+		for platform, a := range b.Platforms {
+			check("binary "+b.Name+" ("+platform+")", a)
+		}
+	}
+	for _, m := range c.Models {
+		for _, a := range m.Files {
+			check("model "+m.Service+"/"+m.Name, a)
+		}
+	}
+	for _, v := range c.Voices {
+		for _, a := range v.Files {
+			check("voice "+v.Name, a)
+		}
+	}
+}
+
+func TestParseRejectsInvalidChecksums(t *testing.T) {
+	tests := []struct {
+		name     string
+		artifact string
+		want     string
+	}{
+		{"short sha256", `"sha256": "abc123", "size": 10`, "invalid sha256"},
+		{"not hex", `"sha256": "` + strings.Repeat("zz", 32) + `", "size": 10`, "invalid sha256"},
+		{"negative size", `"sha256": "` + strings.Repeat("ab", 32) + `", "size": -1`, "invalid size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"version": 1, "models": [{"name": "m", "service": "stt", "files": [
+				{"file": "m.bin", "urls": ["https://example.com/m.bin"], ` + tt.artifact + `}]}]}`
+			if _, err := Parse([]byte(data)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	valid := `{"version": 1, "models": [{"name": "m", "service": "stt", "files": [
+		{"file": "m.bin", "urls": ["https://example.com/m.bin"], "sha256": "` + strings.Repeat("AB", 32) + `", "size": 10}]}]}`
+	if _, err := Parse([]byte(valid)); err != nil {
+		t.Errorf("valid catalog rejected: %v", err)
+	}
+}
@@ -7,9 +7,10 @@ import (
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig
-	Models   ModelsConfig
-	Features FeaturesConfig
+	Server      ServerConfig
+	Models      ModelsConfig
+	VectorStore VectorStoreConfig
+	Features    FeaturesConfig
 }
 
 // ServerConfig holds server configuration
@@ -19,14 +20,26 @@ type ServerConfig struct {
 
 // ModelsConfig holds model configuration
 type ModelsConfig struct {
-	Whisper WhisperConfig
-	Piper   PiperConfig
-	MiniLM  MiniLMConfig
+	Whisper  WhisperConfig
+	Piper    PiperConfig
+	MiniLM   MiniLMConfig
+	WakeWord WakeWordConfig
+
+	// ManifestPath points to an optional sha256sum-style file used to verify downloads
+	ManifestPath string
+
+	// CatalogPath overrides the built-in model catalog with a JSON file
+	CatalogPath string
 }
 
 // WhisperConfig holds Whisper model configuration
 type WhisperConfig struct {
+	// Path is a directory of ggml models or a single model file; empty uses
+	// the app's models directory
 	Path string
+
+	// Model names the catalog model to start with, e.g. small.en
+	Model string
 }
 
 // PiperConfig holds Piper model configuration
@@ -37,6 +50,31 @@ type PiperConfig struct {
 // MiniLMConfig holds MiniLM model configuration
 type MiniLMConfig struct {
 	Path string
+
+	// BatchSize caps the number of texts embedded in one model run
+	BatchSize int
+}
+
+// WakeWordConfig holds wake-word model configuration
+type WakeWordConfig struct {
+	Path string
+
+	// Model names the catalog keyword model, e.g. hey_jarvis. The catalog only
+	// ships openWakeWord's pretrained keywords (hey_jarvis, hey_mycroft, alexa).
+	Model string
+
+	// KeywordModel is the path of a custom openWakeWord keyword classifier.
+	// There is no pretrained "hey alice" model, so waking on it requires
+	// training one (e.g. hey_alice.onnx) and pointing WAKEWORD_KEYWORD_MODEL at it.
+	KeywordModel string
+
+	// Sensitivity is the default detection sensitivity from 0 to 1
+	Sensitivity float64
+}
+
+// VectorStoreConfig holds the location of persistent embedding collections
+type VectorStoreConfig struct {
+	Path string
 }
 
 // FeaturesConfig holds feature flags
@@ -44,6 +82,7 @@ type FeaturesConfig struct {
 	STT        bool
 	TTS        bool
 	Embeddings bool
+	WakeWord   bool
 }
 
 // LoadConfig loads configuration from environment variables
@@ -54,19 +93,33 @@ func LoadConfig() *Config {
 		},
 		Models: ModelsConfig{
 			Whisper: WhisperConfig{
-				Path: getEnv("WHISPER_MODEL_PATH", "./models/whisper-base"),
+				Path:  getEnv("WHISPER_MODEL_PATH", ""),
+				Model: getEnv("WHISPER_MODEL", ""),
 			},
 			Piper: PiperConfig{
 				Path: getEnv("PIPER_MODEL_PATH", "./models/piper"),
 			},
 			MiniLM: MiniLMConfig{
-				Path: getEnv("MINILM_MODEL_PATH", "./models/minilm"),
+				Path:      getEnv("MINILM_MODEL_PATH", "./models/minilm"),
+				BatchSize: getIntEnv("EMBEDDINGS_BATCH_SIZE", 32),
+			},
+			WakeWord: WakeWordConfig{
+				Path:         getEnv("WAKEWORD_MODEL_PATH", "./models/wakeword"),
+				Model:        getEnv("WAKEWORD_MODEL", ""),
+				KeywordModel: getEnv("WAKEWORD_KEYWORD_MODEL", ""),
+				Sensitivity:  getFloatEnv("WAKEWORD_SENSITIVITY", 0.5),
 			},
+			ManifestPath: getEnv("MODEL_MANIFEST_PATH", ""),
+			CatalogPath:  getEnv("MODEL_CATALOG_PATH", ""),
+		},
+		VectorStore: VectorStoreConfig{
+			Path: getEnv("VECTOR_STORE_PATH", "./data/vectors"),
 		},
 		Features: FeaturesConfig{
 			STT:        getBoolEnv("ENABLE_STT", true),
 			TTS:        getBoolEnv("ENABLE_TTS", true),
 			Embeddings: getBoolEnv("ENABLE_EMBEDDINGS", true),
+			WakeWord:   getBoolEnv("ENABLE_WAKEWORD", false),
 		},
 	}
 }
@@ -79,6 +132,26 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getIntEnv gets an integer environment variable with a default value
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// getFloatEnv gets a floating-point environment variable with a default value
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getBoolEnv gets a boolean environment variable with a default value
 func getBoolEnv(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -1,145 +1,451 @@
 package downloader
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+
+	"alice-backend/internal/events"
+)
+
+// progressEventInterval limits how often progress events are published per download
+const progressEventInterval = 250 * time.Millisecond
+
+const (
+	// defaultMaxRetries is the number of attempts made against each mirror
+	defaultMaxRetries = 3
+
+	// stallTimeout aborts an attempt when no data arrives for this long
+	stallTimeout = 60 * time.Second
+
+	// partSuffix is appended to the destination path while a download is in progress
+	partSuffix = ".part"
+
+	userAgent = "AliceElectron/1.0 (compatible; file downloader)"
 )
 
+// ErrChecksumMismatch is returned when a downloaded file does not match its expected SHA-256
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// File describes a single file to fetch. URLs are mirrors tried in order.
+// SHA256 and Size are optional; when set, the download is verified against them.
+type File struct {
+	URLs     []string
+	DestPath string
+	SHA256   string
+	Size     int64
+}
+
+// Progress is the payload of download events
+type Progress struct {
+	URL           string `json:"url"`
+	Path          string `json:"path"`
+	BytesReceived int64  `json:"bytes_received"`
+	TotalBytes    int64  `json:"total_bytes"`
+	Error         string `json:"error,omitempty"`
+}
+
+// ProgressFunc receives the number of bytes written so far and the expected
+// total (-1 when the server did not report a Content-Length)
+type ProgressFunc func(bytesReceived, total int64)
+
 // Downloader handles model downloads
 type Downloader struct {
-	logger *log.Logger
-	client *http.Client
+	logger     *log.Logger
+	client     *http.Client
+	manifest   Manifest
+	maxRetries int
 }
 
 // NewDownloader creates a new downloader instance
 func NewDownloader(logger *log.Logger) *Downloader {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = 60 * time.Second
+
 	return &Downloader{
 		logger: logger,
+		// No overall timeout: large models can take a long time on slow links.
+		// Stalled transfers are caught by stallTimeout instead.
 		client: &http.Client{
-			Timeout: 5 * time.Minute,
+			Transport: transport,
 		},
+		manifest:   defaultManifest,
+		maxRetries: defaultMaxRetries,
 	}
 }
 
+// SetManifest sets the checksums used to verify files that don't carry their own
+func (d *Downloader) SetManifest(manifest Manifest) {
+	d.manifest = manifest
+}
+
 // Download downloads a file from URL to destination
 func (d *Downloader) Download(url, destPath string) error {
+	return d.Fetch(context.Background(), File{URLs: []string{url}, DestPath: destPath}, nil)
+}
+
+// DownloadWithProgress downloads with progress logging
+func (d *Downloader) DownloadWithProgress(url, destPath string) error {
+	return d.Fetch(context.Background(), File{URLs: []string{url}, DestPath: destPath}, nil)
+}
+
+// DownloadWithCallback downloads a file, reporting progress through onProgress.
+// The download is aborted when ctx is cancelled.
+func (d *Downloader) DownloadWithCallback(ctx context.Context, url, destPath string, onProgress ProgressFunc) error {
+	return d.Fetch(ctx, File{URLs: []string{url}, DestPath: destPath}, onProgress)
+}
+
+// Fetch downloads a file, trying each mirror with retries. Data is written to
+// a temporary ".part" file that is resumed with an HTTP Range request after an
+// interruption, verified, and only then renamed to the destination path.
+func (d *Downloader) Fetch(ctx context.Context, file File, onProgress ProgressFunc) error {
+	if len(file.URLs) == 0 {
+		return fmt.Errorf("no download URLs for %s", file.DestPath)
+	}
+
+	file = d.withManifest(file)
+
 	// Create directory if it doesn't exist
-	dir := filepath.Dir(destPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(file.DestPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Check if file already exists
-	if _, err := os.Stat(destPath); err == nil {
-		d.logger.Printf("File already exists: %s", destPath)
-		return nil
+	// An existing file is only trusted if it passes verification
+	if _, err := os.Stat(file.DestPath); err == nil {
+		err := d.verify(file, file.DestPath)
+		if err == nil {
+			d.logger.Printf("File already exists: %s", file.DestPath)
+			return nil
+		}
+		d.logger.Printf("Existing file %s failed verification, downloading again: %v", file.DestPath, err)
+		os.Remove(file.DestPath)
 	}
 
-	d.logger.Printf("Downloading %s to %s", url, destPath)
+	var lastErr error
+	for i, url := range file.URLs {
+		for attempt := 1; attempt <= d.maxRetries; attempt++ {
+			if attempt > 1 {
+				// Exponential backoff: wait 2, 4, 8 seconds between retries
+				waitTime := time.Duration(1<<uint(attempt-2)) * 2 * time.Second
+				d.logger.Printf("Retrying download in %v (attempt %d/%d)", waitTime, attempt, d.maxRetries)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(waitTime):
+				}
+			}
 
-	// Create request
-	resp, err := d.client.Get(url)
+			d.logger.Printf("Downloading %s to %s (source %d/%d, attempt %d/%d)",
+				url, file.DestPath, i+1, len(file.URLs), attempt, d.maxRetries)
+
+			err := d.fetchOnce(ctx, url, file, onProgress)
+			if err == nil {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			lastErr = err
+			d.logger.Printf("Download attempt failed: %v", err)
+
+			var permanent *permanentError
+			if errors.As(err, &permanent) {
+				// Retrying the same source won't help, move on to the next mirror
+				break
+			}
+		}
+	}
+
+	return fmt.Errorf("failed to download %s from any source: %w", filepath.Base(file.DestPath), lastErr)
+}
+
+// fetchOnce performs a single download attempt, resuming a previous partial file when possible
+func (d *Downloader) fetchOnce(ctx context.Context, url string, file File, onProgress ProgressFunc) error {
+	partPath := file.DestPath + partSuffix
+
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	// Abort the attempt when the transfer stalls
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stallTimer := time.AfterFunc(stallTimeout, cancel)
+	defer stallTimer.Stop()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return &permanentError{fmt.Errorf("failed to create request: %w", err)}
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/octet-stream, */*")
+	req.Header.Set("Accept-Encoding", "identity")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := d.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	flags := os.O_CREATE | os.O_WRONLY
+	total := resp.ContentLength
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Server ignored the range (or there was none): start over
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			os.Remove(partPath)
+			return fmt.Errorf("server returned unexpected range %q", resp.Header.Get("Content-Range"))
+		}
+		d.logger.Printf("Resuming download of %s at %d bytes", filepath.Base(file.DestPath), offset)
+		flags |= os.O_APPEND
+		total = size
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file already holds everything the server has
+		return d.finalize(file, partPath)
+	case http.StatusNotFound, http.StatusForbidden, http.StatusUnauthorized, http.StatusGone:
+		return &permanentError{fmt.Errorf("download failed with status: %d", resp.StatusCode)}
+	default:
 		return fmt.Errorf("download failed with status: %d", resp.StatusCode)
 	}
 
-	// Create destination file
-	out, err := os.Create(destPath)
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return &permanentError{fmt.Errorf("server returned an HTML page instead of %s", filepath.Base(file.DestPath))}
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return &permanentError{fmt.Errorf("failed to create file: %w", err)}
+	}
+
+	progressReader := &progressReader{
+		reader:        resp.Body,
+		total:         total,
+		logger:        d.logger,
+		bytesReceived: offset,
+		onProgress:    onProgress,
+		url:           url,
+		destPath:      file.DestPath,
+		onRead:        func() { stallTimer.Reset(stallTimeout) },
 	}
-	defer out.Close()
+	progressReader.publish(events.TypeDownloadStarted, "")
 
-	// Copy data
-	written, err := io.Copy(out, resp.Body)
+	written, err := io.Copy(out, progressReader)
+	if syncErr := out.Sync(); err == nil {
+		err = syncErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
+		// Keep the partial file so the next attempt can resume it
+		progressReader.publish(events.TypeDownloadFailed, err.Error())
 		return fmt.Errorf("failed to save file: %w", err)
 	}
 
-	d.logger.Printf("Downloaded %d bytes to %s", written, destPath)
+	if err := d.finalize(file, partPath); err != nil {
+		progressReader.publish(events.TypeDownloadFailed, err.Error())
+		return err
+	}
+	progressReader.publish(events.TypeDownloadCompleted, "")
+
+	d.logger.Printf("Downloaded %d bytes to %s", offset+written, file.DestPath)
 	return nil
 }
 
-// DownloadWithProgress downloads with progress logging
-func (d *Downloader) DownloadWithProgress(url, destPath string) error {
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(destPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+// finalize verifies a completed partial file and moves it into place
+func (d *Downloader) finalize(file File, partPath string) error {
+	if err := d.verify(file, partPath); err != nil {
+		// A corrupt partial file can't be resumed, start from scratch next time
+		os.Remove(partPath)
+		return err
 	}
 
-	// Check if file already exists
-	if _, err := os.Stat(destPath); err == nil {
-		d.logger.Printf("File already exists: %s", destPath)
-		return nil
+	if err := os.Rename(partPath, file.DestPath); err != nil {
+		return fmt.Errorf("failed to move download into place: %w", err)
 	}
+	return nil
+}
 
-	d.logger.Printf("Downloading %s to %s", url, destPath)
+// Verify checks that a file exists at its destination and matches its
+// expected size and SHA-256, when known
+func (d *Downloader) Verify(file File) error {
+	file = d.withManifest(file)
+	return d.verify(file, file.DestPath)
+}
 
-	// Create request
-	resp, err := d.client.Get(url)
+// withManifest fills in a missing checksum from the manifest
+func (d *Downloader) withManifest(file File) File {
+	if file.SHA256 == "" {
+		file.SHA256 = d.manifest.Lookup(file.DestPath)
+	}
+	return file
+}
+
+// verify checks a file against the expected size and SHA-256, when known
+func (d *Downloader) verify(file File, path string) error {
+	info, err := os.Stat(path)
 	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status: %d", resp.StatusCode)
+	if file.Size > 0 && info.Size() != file.Size {
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", filepath.Base(file.DestPath), file.Size, info.Size())
 	}
 
-	// Get content length for progress
-	contentLength := resp.ContentLength
+	if file.SHA256 == "" {
+		// Callers keep or install the file after this, so make the gap visible
+		d.logger.Printf("Warning: no SHA-256 known for %s, using it unverified", filepath.Base(file.DestPath))
+		return nil
+	}
 
-	// Create destination file
-	out, err := os.Create(destPath)
+	sum, err := SHA256File(path)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return fmt.Errorf("failed to hash %s: %w", path, err)
 	}
-	defer out.Close()
+	if !strings.EqualFold(sum, file.SHA256) {
+		return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, filepath.Base(file.DestPath), file.SHA256, sum)
+	}
+	return nil
+}
 
-	// Create progress reader
-	progressReader := &progressReader{
-		reader:        resp.Body,
-		total:         contentLength,
-		logger:        d.logger,
-		bytesReceived: 0,
+// ContentLength asks the server for the size of a file without downloading it.
+// It returns -1 when the size is unknown.
+func (d *Downloader) ContentLength(ctx context.Context, url string) int64 {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return -1
 	}
+	req.Header.Set("User-Agent", userAgent)
 
-	// Copy data with progress
-	written, err := io.Copy(out, progressReader)
+	resp, err := d.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+		return -1
 	}
+	resp.Body.Close()
 
-	d.logger.Printf("Downloaded %d bytes to %s", written, destPath)
-	return nil
+	if resp.StatusCode != http.StatusOK {
+		return -1
+	}
+	return resp.ContentLength
+}
+
+// SHA256File returns the hex-encoded SHA-256 digest of a file
+func SHA256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
-// progressReader wraps an io.Reader to log progress
+// permanentError marks failures that retrying the same URL cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// parseContentRange parses a "bytes start-end/size" header. size is -1 when unknown.
+func parseContentRange(header string) (start, size int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	rangePart, sizePart, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	startPart, _, found := strings.Cut(rangePart, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(startPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	size = -1
+	if sizePart != "*" {
+		if size, err = strconv.ParseInt(sizePart, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, size, true
+}
+
+// progressReader wraps an io.Reader to log and publish progress
 type progressReader struct {
 	reader        io.Reader
 	total         int64
 	logger        *log.Logger
 	bytesReceived int64
+	lastLogged    int
+	onProgress    ProgressFunc
+	onRead        func()
+	url           string
+	destPath      string
+	lastPublished time.Time
+}
+
+// publish sends a download event describing the current state of the transfer
+func (pr *progressReader) publish(eventType, errMsg string) {
+	pr.lastPublished = time.Now()
+	events.Publish(eventType, "", Progress{
+		URL:           pr.url,
+		Path:          pr.destPath,
+		BytesReceived: pr.bytesReceived,
+		TotalBytes:    pr.total,
+		Error:         errMsg,
+	})
 }
 
 func (pr *progressReader) Read(p []byte) (int, error) {
 	n, err := pr.reader.Read(p)
 	pr.bytesReceived += int64(n)
 
+	if pr.onRead != nil && n > 0 {
+		pr.onRead()
+	}
+
+	if pr.onProgress != nil && n > 0 {
+		pr.onProgress(pr.bytesReceived, pr.total)
+	}
+
+	if n > 0 && time.Since(pr.lastPublished) >= progressEventInterval {
+		pr.publish(events.TypeDownloadProgress, "")
+	}
+
 	if pr.total > 0 {
 		percentage := float64(pr.bytesReceived) * 100.0 / float64(pr.total)
-		if int(percentage)%10 == 0 {
+		if step := int(percentage) / 10; step > pr.lastLogged {
+			pr.lastLogged = step
 			pr.logger.Printf("Download progress: %.1f%%", percentage)
 		}
 	}
@@ -0,0 +1,69 @@
+package downloader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Manifest maps file names to their expected SHA-256 digests
+type Manifest map[string]string
+
+var defaultManifest Manifest
+
+// SetDefaultManifest sets the manifest used by downloaders created afterwards
+func SetDefaultManifest(manifest Manifest) {
+	defaultManifest = manifest
+}
+
+// ParseManifest reads a manifest in sha256sum format: one "<hex digest>  <file name>"
+// entry per line. Blank lines and lines starting with '#' are ignored.
+func ParseManifest(r io.Reader) (Manifest, error) {
+	manifest := make(Manifest)
+	scanner := bufio.NewScanner(r)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != 64 {
+			return nil, fmt.Errorf("invalid manifest entry on line %d", lineNum)
+		}
+
+		// sha256sum marks binary mode with a leading '*'
+		name := strings.TrimPrefix(fields[1], "*")
+		manifest[filepath.Base(name)] = strings.ToLower(fields[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// LoadManifest reads a manifest file from disk
+func LoadManifest(path string) (Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseManifest(f)
+}
+
+// Lookup returns the expected digest for a destination path, or "" if unknown
+func (m Manifest) Lookup(path string) string {
+	if m == nil {
+		return ""
+	}
+	return m[filepath.Base(path)]
+}
@@ -14,6 +14,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/events"
 )
 
 // Embed all platform-specific binaries and data files
@@ -27,6 +30,13 @@ type AssetManager struct {
 	cache   map[string]string // asset -> extracted path
 }
 
+// ExtractionStep is the payload of extraction events
+type ExtractionStep struct {
+	Asset  string `json:"asset"`
+	Target string `json:"target"`
+	Error  string `json:"error,omitempty"`
+}
+
 // PlatformInfo holds platform-specific asset information
 type PlatformInfo struct {
 	OS           string
@@ -40,10 +50,18 @@ type PlatformInfo struct {
 // GetPlatformInfo returns platform-specific paths and requirements
 func GetPlatformInfo() *PlatformInfo {
 	info := &PlatformInfo{
-		OS:           runtime.GOOS,
-		Arch:         runtime.GOARCH,
-		WhisperModel: "whisper-base.bin",
-		PiperVoices:  []string{"en_US-amy-medium", "en_US-hfc_female-medium", "en_US-kristin-medium"},
+		OS:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+	}
+
+	modelCatalog := catalog.Default()
+	if model, ok := modelCatalog.DefaultModel("stt"); ok {
+		info.WhisperModel = model.Files[0].File
+	}
+	for _, voice := range modelCatalog.Voices {
+		if voice.Bundled {
+			info.PiperVoices = append(info.PiperVoices, voice.Name)
+		}
 	}
 
 	switch runtime.GOOS {
@@ -199,7 +217,9 @@ func (am *AssetManager) extractWhisperAssets(ctx context.Context, info *Platform
 	log.Printf("Extracting embedded Whisper assets from: %s", archivePath)
 	// Extract archive to bin directory
 	binDir := filepath.Join(am.baseDir, "bin")
-	return am.extractEmbeddedZip(archivePath, binDir)
+	return am.trackExtraction(archivePath, binDir, func() error {
+		return am.extractEmbeddedZip(archivePath, binDir)
+	})
 }
 
 // extractPiperAssets extracts Piper binary and espeak-ng data
@@ -230,11 +250,12 @@ func (am *AssetManager) extractPiperAssets(ctx context.Context, info *PlatformIn
 	
 	log.Printf("Extracting embedded Piper assets from: %s", archivePath)
 	binDir := filepath.Join(am.baseDir, "bin")
-	if isZip {
-		return am.extractEmbeddedZip(archivePath, binDir)
-	} else {
+	return am.trackExtraction(archivePath, binDir, func() error {
+		if isZip {
+			return am.extractEmbeddedZip(archivePath, binDir)
+		}
 		return am.extractEmbeddedTarGz(archivePath, binDir)
-	}
+	})
 }
 
 // extractVoiceModels extracts voice model files
@@ -245,7 +266,9 @@ func (am *AssetManager) extractVoiceModels(ctx context.Context, info *PlatformIn
 	whisperModelPath := fmt.Sprintf("assets/models/%s", info.WhisperModel)
 	if _, err := EmbeddedAssets.Open(whisperModelPath); err == nil {
 		targetPath := filepath.Join(modelsDir, info.WhisperModel)
-		if err := am.extractEmbeddedFile(whisperModelPath, targetPath); err != nil {
+		if err := am.trackExtraction(whisperModelPath, targetPath, func() error {
+			return am.extractEmbeddedFile(whisperModelPath, targetPath)
+		}); err != nil {
 			log.Printf("Warning: Failed to extract Whisper model: %v", err)
 		} else {
 			log.Printf("Extracted embedded Whisper model: %s", targetPath)
@@ -266,7 +289,9 @@ func (am *AssetManager) extractVoiceModels(ctx context.Context, info *PlatformIn
 		
 		if _, err := EmbeddedAssets.Open(onnxPath); err == nil {
 			targetPath := filepath.Join(piperModelsDir, fmt.Sprintf("%s.onnx", voice))
-			if err := am.extractEmbeddedFile(onnxPath, targetPath); err != nil {
+			if err := am.trackExtraction(onnxPath, targetPath, func() error {
+				return am.extractEmbeddedFile(onnxPath, targetPath)
+			}); err != nil {
 				log.Printf("Warning: Failed to extract voice model %s: %v", voice, err)
 			} else {
 				log.Printf("Extracted voice model: %s", targetPath)
@@ -286,6 +311,19 @@ func (am *AssetManager) extractVoiceModels(ctx context.Context, info *PlatformIn
 	return nil
 }
 
+// trackExtraction runs an extraction step and publishes its start and outcome
+func (am *AssetManager) trackExtraction(asset, target string, extract func() error) error {
+	events.Publish(events.TypeExtractionStarted, "", ExtractionStep{Asset: asset, Target: target})
+
+	if err := extract(); err != nil {
+		events.Publish(events.TypeExtractionFailed, "", ExtractionStep{Asset: asset, Target: target, Error: err.Error()})
+		return err
+	}
+
+	events.Publish(events.TypeExtractionCompleted, "", ExtractionStep{Asset: asset, Target: target})
+	return nil
+}
+
 // extractEmbeddedZip extracts a ZIP archive from embedded assets
 func (am *AssetManager) extractEmbeddedZip(archivePath, targetDir string) error {
 	archiveData, err := EmbeddedAssets.ReadFile(archivePath)
@@ -475,7 +513,7 @@ func (am *AssetManager) GetModelPath(modelName string) string {
 	
 	switch modelName {
 	case "whisper":
-		return filepath.Join(modelsDir, "whisper-base.bin")
+		return filepath.Join(modelsDir, GetPlatformInfo().WhisperModel)
 	default:
 		return filepath.Join(modelsDir, modelName)
 	}
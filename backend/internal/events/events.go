@@ -0,0 +1,104 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published by the backend
+const (
+	// Emitted by the downloader for individual files
+	TypeDownloadStarted   = "download.started"
+	TypeDownloadProgress  = "download.progress"
+	TypeDownloadCompleted = "download.completed"
+	TypeDownloadFailed    = "download.failed"
+
+	// Emitted by the asset manager while unpacking archives
+	TypeExtractionStarted   = "extraction.started"
+	TypeExtractionCompleted = "extraction.completed"
+	TypeExtractionFailed    = "extraction.failed"
+
+	// Emitted by the model manager
+	TypeJobUpdated    = "job.updated"
+	TypeServiceStatus = "service.status"
+)
+
+// subscriberBuffer is the number of events queued per subscriber before new ones are dropped
+const subscriberBuffer = 64
+
+// Event is a single notification sent to subscribers
+type Event struct {
+	Type    string      `json:"type"`
+	Service string      `json:"service,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+	Time    time.Time   `json:"time"`
+}
+
+// Bus fans out events to any number of subscribers. Publishing never blocks:
+// a subscriber that falls behind misses events instead of stalling producers.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. The returned function unsubscribes
+// and closes the channel.
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends an event to all current subscribers
+func (b *Bus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+var defaultBus = NewBus()
+
+// Default returns the process-wide event bus
+func Default() *Bus {
+	return defaultBus
+}
+
+// Publish sends an event on the default bus
+func Publish(eventType, service string, data interface{}) {
+	defaultBus.Publish(Event{
+		Type:    eventType,
+		Service: service,
+		Data:    data,
+	})
+}
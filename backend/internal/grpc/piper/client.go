@@ -3,9 +3,11 @@ package piper
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
+	"alice-backend/internal/piper"
 	piperv1 "alice-backend/proto/piper/v1"
 
 	"google.golang.org/grpc"
@@ -96,7 +98,7 @@ func (c *Client) HealthCheck(ctx context.Context) (bool, error) {
 }
 
 // Synthesize sends a text-to-speech request to the Piper service
-func (c *Client) Synthesize(ctx context.Context, text, voice string, speed float32) ([]byte, error) {
+func (c *Client) Synthesize(ctx context.Context, text, voice string, speed float32, opts piper.SynthesisOptions) ([]byte, error) {
 	if c.client == nil {
 		return nil, fmt.Errorf("client not connected")
 	}
@@ -113,9 +115,16 @@ func (c *Client) Synthesize(ctx context.Context, text, voice string, speed float
 	log.Printf("[PiperClient] Sending synthesis request for voice: %s, text length: %d", voice, len(text))
 
 	req := &piperv1.SynthesizeRequest{
-		Text:  text,
-		Voice: voice,
-		Speed: speed,
+		Text:    text,
+		Voice:   voice,
+		Speed:   speed,
+		Speaker: opts.Speaker,
+
+		LengthScale:     opts.LengthScale,
+		NoiseScale:      opts.NoiseScale,
+		NoiseW:          opts.NoiseW,
+		SentenceSilence: opts.SentenceSilence,
+		SpeakerId:       speakerID(opts),
 	}
 
 	resp, err := c.client.Synthesize(ctx, req)
@@ -129,6 +138,60 @@ func (c *Client) Synthesize(ctx context.Context, text, voice string, speed float
 	return resp.AudioData, nil
 }
 
+// SynthesizeStream requests sentence-by-sentence synthesis and calls onChunk for
+// every sentence as it arrives
+func (c *Client) SynthesizeStream(ctx context.Context, text, voice string, speed float32, opts piper.SynthesisOptions, onChunk func(piper.AudioChunk) error) error {
+	if c.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+
+	if text == "" {
+		return fmt.Errorf("text cannot be empty")
+	}
+
+	if speed == 0 {
+		speed = 1.0
+	}
+
+	stream, err := c.client.SynthesizeStream(ctx, &piperv1.SynthesizeRequest{
+		Text:    text,
+		Voice:   voice,
+		Speed:   speed,
+		Speaker: opts.Speaker,
+
+		LengthScale:     opts.LengthScale,
+		NoiseScale:      opts.NoiseScale,
+		NoiseW:          opts.NoiseW,
+		SentenceSilence: opts.SentenceSilence,
+		SpeakerId:       speakerID(opts),
+	})
+	if err != nil {
+		return fmt.Errorf("synthesis failed: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("synthesis stream failed: %w", err)
+		}
+
+		if err := onChunk(piper.AudioChunk{
+			Index:         int(resp.Index),
+			Text:          resp.Text,
+			PCM:           resp.PcmData,
+			SampleRate:    int(resp.SampleRate),
+			Channels:      int(resp.Channels),
+			BitsPerSample: int(resp.BitsPerSample),
+			Final:         resp.Final,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
 // GetVoices retrieves the list of available voices from the service
 func (c *Client) GetVoices(ctx context.Context) ([]*piperv1.Voice, error) {
 	if c.client == nil {
@@ -166,3 +229,12 @@ func (c *Client) String() string {
 	}
 	return fmt.Sprintf("PiperClient{address: %s, connected: false}", c.address)
 }
+
+// speakerID converts the numeric speaker id of opts to its protobuf field
+func speakerID(opts piper.SynthesisOptions) *int32 {
+	if opts.SpeakerID == nil {
+		return nil
+	}
+	id := int32(*opts.SpeakerID)
+	return &id
+}
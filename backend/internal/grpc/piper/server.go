@@ -2,6 +2,7 @@ package piper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -69,7 +70,10 @@ func (s *Server) Synthesize(ctx context.Context, req *piperv1.SynthesizeRequest)
 	startTime := time.Now()
 
 	// Perform synthesis
-	audioData, err := s.ttsService.Synthesize(ctx, req.Text, req.Voice)
+	audioData, err := s.ttsService.SynthesizeWithOptions(ctx, req.Text, req.Voice, requestOptions(req))
+	if errors.Is(err, piper.ErrInvalidOptions) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		log.Printf("[gRPC] Synthesis failed: %v", err)
 		return nil, status.Errorf(codes.Internal, "synthesis failed: %v", err)
@@ -81,16 +85,74 @@ func (s *Server) Synthesize(ctx context.Context, req *piperv1.SynthesizeRequest)
 
 	log.Printf("[gRPC] Synthesis completed in %dms, audio size: %d bytes", durationMs, len(audioData))
 
+	sampleRate := int32(22050) // Piper default sample rate
+	if wavInfo, err := piper.ParseWAVInfo(audioData); err == nil {
+		sampleRate = int32(wavInfo.SampleRate)
+	}
+
 	// Build response
 	response := &piperv1.SynthesizeResponse{
 		AudioData:  audioData,
-		SampleRate: 22050, // Piper default sample rate
+		SampleRate: sampleRate,
 		DurationMs: durationMs,
 	}
 
 	return response, nil
 }
 
+// SynthesizeStream converts text to speech sentence by sentence
+func (s *Server) SynthesizeStream(req *piperv1.SynthesizeRequest, stream piperv1.PiperService_SynthesizeStreamServer) error {
+	log.Printf("[gRPC] SynthesizeStream called for voice: %s, text length: %d chars", req.Voice, len(req.Text))
+
+	if req.Text == "" {
+		return status.Error(codes.InvalidArgument, "text cannot be empty")
+	}
+
+	if !s.ttsService.IsReady() {
+		return status.Error(codes.Unavailable, "Piper TTS service is not ready")
+	}
+
+	startTime := time.Now()
+	err := s.ttsService.SynthesizeStream(stream.Context(), req.Text, req.Voice, requestOptions(req), func(chunk piper.AudioChunk) error {
+		return stream.Send(&piperv1.SynthesizeChunk{
+			Index:         int32(chunk.Index),
+			Text:          chunk.Text,
+			PcmData:       chunk.PCM,
+			SampleRate:    int32(chunk.SampleRate),
+			Channels:      int32(chunk.Channels),
+			BitsPerSample: int32(chunk.BitsPerSample),
+			Final:         chunk.Final,
+		})
+	})
+	if errors.Is(err, piper.ErrInvalidOptions) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("[gRPC] Streaming synthesis failed: %v", err)
+		return status.Errorf(codes.Internal, "synthesis failed: %v", err)
+	}
+
+	log.Printf("[gRPC] Streaming synthesis completed in %dms", time.Since(startTime).Milliseconds())
+	return nil
+}
+
+// requestOptions extracts the per-request synthesis settings
+func requestOptions(req *piperv1.SynthesizeRequest) piper.SynthesisOptions {
+	opts := piper.SynthesisOptions{
+		Speaker:         req.Speaker,
+		Speed:           req.Speed,
+		LengthScale:     req.LengthScale,
+		NoiseScale:      req.NoiseScale,
+		NoiseW:          req.NoiseW,
+		SentenceSilence: req.SentenceSilence,
+	}
+	if req.SpeakerId != nil {
+		id := int(*req.SpeakerId)
+		opts.SpeakerID = &id
+	}
+	return opts
+}
+
 // GetVoices returns the list of available voice models
 func (s *Server) GetVoices(ctx context.Context, req *piperv1.GetVoicesRequest) (*piperv1.GetVoicesResponse, error) {
 	log.Println("[gRPC] GetVoices called")
@@ -109,6 +171,7 @@ func (s *Server) GetVoices(ctx context.Context, req *piperv1.GetVoicesRequest) (
 			Quality:     v.Quality,
 			SampleRate:  int32(v.SampleRate),
 			Description: v.Description,
+			Speakers:    v.Speakers,
 		}
 	}
 
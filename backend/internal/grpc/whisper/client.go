@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"alice-backend/internal/whisper"
 	whisperv1 "alice-backend/proto/whisper/v1"
 
 	"google.golang.org/grpc"
@@ -92,12 +93,21 @@ func (c *Client) HealthCheck(ctx context.Context) (bool, error) {
 
 // Transcribe sends audio data to the Whisper service for transcription
 func (c *Client) Transcribe(ctx context.Context, audioData []byte, language string) (string, error) {
+	result, err := c.TranscribeDetailed(ctx, audioData, language)
+	if err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
+
+// TranscribeDetailed transcribes audio and returns segments with timestamps
+func (c *Client) TranscribeDetailed(ctx context.Context, audioData []byte, language string) (*whisper.Transcription, error) {
 	if c.client == nil {
-		return "", fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
 	}
 
 	if len(audioData) == 0 {
-		return "", fmt.Errorf("audio data cannot be empty")
+		return nil, fmt.Errorf("audio data cannot be empty")
 	}
 
 	log.Printf("[WhisperClient] Sending %d bytes of audio for transcription (language: %s)", len(audioData), language)
@@ -110,12 +120,39 @@ func (c *Client) Transcribe(ctx context.Context, audioData []byte, language stri
 
 	resp, err := c.client.Transcribe(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("transcription failed: %w", err)
+		return nil, fmt.Errorf("transcription failed: %w", err)
 	}
 
 	log.Printf("[WhisperClient] Transcription completed in %dms: %s", resp.DurationMs, resp.Text)
 
-	return resp.Text, nil
+	result := &whisper.Transcription{
+		Text:       resp.Text,
+		Language:   resp.LanguageDetected,
+		Duration:   resp.AudioDuration,
+		Confidence: float64(resp.Confidence),
+		AvgLogprob: resp.AvgLogprob,
+	}
+	for _, seg := range resp.Segments {
+		segment := whisper.Segment{
+			ID:         int(seg.Id),
+			Start:      seg.Start,
+			End:        seg.End,
+			Text:       seg.Text,
+			AvgLogprob: seg.AvgLogprob,
+			Confidence: seg.Confidence,
+		}
+		for _, word := range seg.Words {
+			segment.Words = append(segment.Words, whisper.Word{
+				Word:        word.Word,
+				Start:       word.Start,
+				End:         word.End,
+				Probability: word.Probability,
+			})
+		}
+		result.Segments = append(result.Segments, segment)
+	}
+
+	return result, nil
 }
 
 // Close closes the gRPC connection
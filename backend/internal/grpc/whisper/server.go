@@ -2,7 +2,9 @@ package whisper
 
 import (
 	"context"
+	"io"
 	"log"
+	"sync"
 	"time"
 
 	whisperv1 "alice-backend/proto/whisper/v1"
@@ -60,11 +62,21 @@ func (s *Server) Transcribe(ctx context.Context, req *whisperv1.TranscribeReques
 		return nil, status.Error(codes.Unavailable, "Whisper STT service is not ready")
 	}
 
+	if req.SampleRate != 0 && (req.SampleRate < 4000 || req.SampleRate > 384000) {
+		return nil, status.Error(codes.InvalidArgument, "sample_rate must be between 4000 and 384000")
+	}
+
 	// Start timing
 	startTime := time.Now()
 
+	// The service expects 16kHz audio
+	audioData, err := whisper.ResamplePCM16(req.AudioData, int(req.SampleRate))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid audio_data: %v", err)
+	}
+
 	// Perform transcription using the existing STT service
-	text, err := s.sttService.TranscribeAudioWithLanguage(ctx, req.AudioData, req.Language)
+	result, err := s.sttService.TranscribeDetailed(ctx, audioData, req.Language)
 	if err != nil {
 		log.Printf("[gRPC] Transcription failed: %v", err)
 		return nil, status.Errorf(codes.Internal, "transcription failed: %v", err)
@@ -74,15 +86,126 @@ func (s *Server) Transcribe(ctx context.Context, req *whisperv1.TranscribeReques
 	duration := time.Since(startTime)
 	durationMs := duration.Milliseconds()
 
-	log.Printf("[gRPC] Transcription completed in %dms: %s", durationMs, text)
+	log.Printf("[gRPC] Transcription completed in %dms: %s", durationMs, result.Text)
+
+	return transcribeResponse(result, durationMs), nil
+}
 
-	// Build response
+// transcribeResponse converts a transcription to its protobuf form
+func transcribeResponse(result *whisper.Transcription, durationMs int64) *whisperv1.TranscribeResponse {
 	response := &whisperv1.TranscribeResponse{
-		Text:             text,
-		LanguageDetected: req.Language, // Whisper CLI doesn't return detected language directly
-		Confidence:       0.95,          // Whisper doesn't provide confidence scores via CLI
+		Text:             result.Text,
+		LanguageDetected: result.Language,
+		Confidence:       float32(result.Confidence),
 		DurationMs:       durationMs,
+		AudioDuration:    result.Duration,
+		AvgLogprob:       result.AvgLogprob,
+	}
+	for _, seg := range result.Segments {
+		segment := &whisperv1.Segment{
+			Id:         int32(seg.ID),
+			Start:      seg.Start,
+			End:        seg.End,
+			Text:       seg.Text,
+			AvgLogprob: seg.AvgLogprob,
+			Confidence: seg.Confidence,
+		}
+		for _, word := range seg.Words {
+			segment.Words = append(segment.Words, &whisperv1.Word{
+				Word:        word.Word,
+				Start:       word.Start,
+				End:         word.End,
+				Probability: word.Probability,
+			})
+		}
+		response.Segments = append(response.Segments, segment)
+	}
+
+	return response
+}
+
+// streamEventTypes maps session events to their protobuf event types
+var streamEventTypes = map[string]whisperv1.StreamTranscribeResponse_EventType{
+	whisper.StreamEventSpeechStart: whisperv1.StreamTranscribeResponse_EVENT_TYPE_SPEECH_START,
+	whisper.StreamEventPartial:     whisperv1.StreamTranscribeResponse_EVENT_TYPE_PARTIAL,
+	whisper.StreamEventFinal:       whisperv1.StreamTranscribeResponse_EVENT_TYPE_FINAL,
+	whisper.StreamEventError:       whisperv1.StreamTranscribeResponse_EVENT_TYPE_ERROR,
+}
+
+// TranscribeStream transcribes live audio, returning partial and final
+// transcripts as utterances are detected. The stream ends with a final
+// transcript of any speech in progress once the client closes its side.
+func (s *Server) TranscribeStream(stream whisperv1.WhisperService_TranscribeStreamServer) error {
+	if !s.sttService.IsReady() {
+		return status.Error(codes.Unavailable, "Whisper STT service is not ready")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	config := first.GetConfig()
+	if config == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the stream config")
+	}
+	if config.SampleRate != 0 && (config.SampleRate < 4000 || config.SampleRate > 384000) {
+		return status.Error(codes.InvalidArgument, "sample_rate must be between 4000 and 384000")
+	}
+	sampleRate := 16000
+	if config.SampleRate != 0 {
+		sampleRate = int(config.SampleRate)
+	}
+
+	log.Printf("[gRPC] TranscribeStream started at %d Hz, language: %s", sampleRate, config.Language)
+
+	// Events arrive from the session's worker as well as this goroutine
+	var sendMu sync.Mutex
+	var sendErr error
+	session := s.sttService.NewStream(stream.Context(), config.Language, func(event whisper.StreamEvent) {
+		response := &whisperv1.StreamTranscribeResponse{
+			Type:  streamEventTypes[event.Type],
+			Text:  event.Text,
+			Start: event.Start,
+			End:   event.End,
+			Error: event.Error,
+		}
+		if event.Result != nil {
+			response.Result = transcribeResponse(event.Result, 0)
+		}
+
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(response)
+		}
+	})
+	resampler := whisper.NewStreamResampler(sampleRate, 16000)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			session.Close()
+			break
+		}
+		if err != nil {
+			session.Abort()
+			return err
+		}
+
+		audio := req.GetAudioData()
+		if audio == nil {
+			session.Abort()
+			return status.Error(codes.InvalidArgument, "expected audio_data after the stream config")
+		}
+		samples, err := whisper.DecodePCM16(audio)
+		if err != nil {
+			session.Abort()
+			return status.Errorf(codes.InvalidArgument, "invalid audio_data: %v", err)
+		}
+		session.Write(resampler.Process(samples))
 	}
 
-	return response, nil
+	sendMu.Lock()
+	defer sendMu.Unlock()
+	return sendErr
 }
@@ -0,0 +1,232 @@
+package minilm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Pooling selects how the chunk embeddings of a document are combined
+type Pooling string
+
+const (
+	// PoolingNone returns one embedding per chunk
+	PoolingNone Pooling = "none"
+	// PoolingMean averages the chunk embeddings
+	PoolingMean Pooling = "mean"
+	// PoolingMax takes the largest value of each dimension across chunks
+	PoolingMax Pooling = "max"
+)
+
+// DefaultChunkOverlap is the number of tokens consecutive chunks share
+const DefaultChunkOverlap = 32
+
+// ErrInvalidDocumentOptions is returned for chunking or pooling options that can't be used
+var ErrInvalidDocumentOptions = errors.New("invalid document options")
+
+// DocumentOptions controls how a document is split and pooled
+type DocumentOptions struct {
+	// ChunkTokens is the window size in WordPiece tokens, not counting
+	// [CLS] and [SEP]; at most and by default the model's limit
+	ChunkTokens int
+	// Overlap is the number of tokens shared by consecutive windows
+	Overlap int
+	// Pooling combines the chunks into one vector; empty means mean
+	Pooling Pooling
+}
+
+// Chunk is a window of a document. Start and End are character (rune)
+// offsets into the document text.
+type Chunk struct {
+	Index     int       `json:"index"`
+	Start     int       `json:"start"`
+	End       int       `json:"end"`
+	Tokens    int       `json:"tokens"`
+	Text      string    `json:"text"`
+	Embedding []float32 `json:"embedding,omitempty"`
+}
+
+// DocumentEmbedding is the result of embedding a document: either one pooled
+// embedding, or the embeddings of its chunks
+type DocumentEmbedding struct {
+	Embedding []float32 `json:"embedding,omitempty"`
+	Pooling   Pooling   `json:"pooling"`
+	Tokens    int       `json:"tokens"`
+	Chunks    []Chunk   `json:"chunks"`
+}
+
+// wordSpan is a basic token with its rune offsets in the source text
+type wordSpan struct {
+	word       string
+	start, end int
+}
+
+// piece is a WordPiece token and the word it came from
+type piece struct {
+	id   int
+	word int
+}
+
+// EmbedDocument embeds a text of any length. The text is split into
+// overlapping token windows that each fit the model, every window is
+// embedded, and the results are pooled unless opts.Pooling is PoolingNone.
+func (s *OnnxEmbeddingService) EmbedDocument(ctx context.Context, text string, opts DocumentOptions) (*DocumentEmbedding, error) {
+	if !s.IsReady() {
+		return nil, fmt.Errorf("embeddings service is not ready")
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	opts, err := resolveDocumentOptions(opts, s.maxLen-2) // Room for [CLS] and [SEP]
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, seqs, tokens := s.chunk(text, opts.ChunkTokens, opts.Overlap)
+	embeddings, err := s.embedSequences(ctx, seqs)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &DocumentEmbedding{Pooling: opts.Pooling, Tokens: tokens, Chunks: chunks}
+	switch opts.Pooling {
+	case PoolingNone:
+		for i := range chunks {
+			chunks[i].Embedding = embeddings[i]
+		}
+	case PoolingMean:
+		doc.Embedding = meanPool(embeddings)
+	case PoolingMax:
+		doc.Embedding = maxPool(embeddings)
+	}
+	return doc, nil
+}
+
+// resolveDocumentOptions fills in the defaults of opts for a model that takes
+// at most limit tokens per window, and checks that they can be used
+func resolveDocumentOptions(opts DocumentOptions, limit int) (DocumentOptions, error) {
+	if opts.ChunkTokens <= 0 || opts.ChunkTokens > limit {
+		opts.ChunkTokens = limit
+	}
+	if opts.Overlap <= 0 {
+		opts.Overlap = min(DefaultChunkOverlap, opts.ChunkTokens/4)
+	}
+	if opts.Overlap >= opts.ChunkTokens {
+		return opts, fmt.Errorf("%w: overlap (%d) must be smaller than the chunk size (%d)", ErrInvalidDocumentOptions, opts.Overlap, opts.ChunkTokens)
+	}
+	if opts.Pooling == "" {
+		opts.Pooling = PoolingMean
+	}
+	if opts.Pooling != PoolingNone && opts.Pooling != PoolingMean && opts.Pooling != PoolingMax {
+		return opts, fmt.Errorf("%w: unknown pooling %q", ErrInvalidDocumentOptions, opts.Pooling)
+	}
+	return opts, nil
+}
+
+// chunk slides a window of the given number of tokens over text and returns
+// the chunks, their model input sequences and the total number of tokens. A
+// text without any tokens is one empty chunk.
+func (s *OnnxEmbeddingService) chunk(text string, window, overlap int) ([]Chunk, [][]int, int) {
+	words := wordSpans(text)
+	var pieces []piece
+	for i, w := range words {
+		for _, id := range s.tokenizer.tokenizeWord(w.word) {
+			pieces = append(pieces, piece{id: id, word: i})
+		}
+	}
+
+	runes := []rune(text)
+	var chunks []Chunk
+	var seqs [][]int
+	for start := 0; ; start += window - overlap {
+		end := min(start+window, len(pieces))
+
+		chunk := Chunk{Index: len(chunks), End: len(runes), Tokens: end - start}
+		if end > start {
+			chunk.Start = words[pieces[start].word].start
+			chunk.End = words[pieces[end-1].word].end
+		}
+		chunk.Text = string(runes[chunk.Start:chunk.End])
+		chunks = append(chunks, chunk)
+
+		seq := []int{s.tokenizer.clsID}
+		for _, p := range pieces[start:end] {
+			seq = append(seq, p.id)
+		}
+		seqs = append(seqs, append(seq, s.tokenizer.sepID))
+
+		if end == len(pieces) {
+			break
+		}
+	}
+	return chunks, seqs, len(pieces)
+}
+
+// wordSpans splits text like basicTokens, keeping each word's rune offsets
+func wordSpans(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	var i int
+	var b strings.Builder
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			b.WriteRune(r)
+		} else if start >= 0 {
+			spans = append(spans, wordSpan{strings.ToLower(b.String()), start, i})
+			start = -1
+			b.Reset()
+		}
+		i++
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{strings.ToLower(b.String()), start, i})
+	}
+	return spans
+}
+
+// meanPool averages embeddings and normalizes the result
+func meanPool(embeddings [][]float32) []float32 {
+	out := make([]float32, len(embeddings[0]))
+	for _, e := range embeddings {
+		for d, v := range e {
+			out[d] += v
+		}
+	}
+	return normalized(out)
+}
+
+// maxPool takes the largest value of each dimension and normalizes the result
+func maxPool(embeddings [][]float32) []float32 {
+	out := append([]float32(nil), embeddings[0]...)
+	for _, e := range embeddings[1:] {
+		for d, v := range e {
+			if v > out[d] {
+				out[d] = v
+			}
+		}
+	}
+	return normalized(out)
+}
+
+// normalized scales v to unit length in place and returns it
+func normalized(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm > 0 {
+		inv := float32(1 / math.Sqrt(norm))
+		for d := range v {
+			v[d] *= inv
+		}
+	}
+	return v
+}
@@ -0,0 +1,172 @@
+package minilm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Token ids of the test vocabulary
+const (
+	testPad = iota
+	testUnk
+	testCLS
+	testSEP
+)
+
+// newTokenizerService returns a service with a tiny in-memory WordPiece
+// vocabulary, enough to tokenize text without the model files
+func newTokenizerService() *OnnxEmbeddingService {
+	vocab := map[string]int{"[PAD]": testPad, "[UNK]": testUnk, "[CLS]": testCLS, "[SEP]": testSEP}
+	for _, token := range []string{"one", "two", "three", "four", "five", "six", "seven", "play", "##ing", "naïve", "café"} {
+		vocab[token] = len(vocab)
+	}
+
+	s := NewOnnxEmbeddingService(&Config{})
+	s.tokenizer = &wordPiece{vocab: vocab, unkID: testUnk, clsID: testCLS, sepID: testSEP, padID: testPad}
+	return s
+}
+
+// chunkTexts returns the text of each chunk
+func chunkTexts(chunks []Chunk) []string {
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = c.Text
+	}
+	return texts
+}
+
+func TestWordSpans(t *testing.T) {
+	tests := []struct {
+		text string
+		want []wordSpan
+	}{
+		{"Hello, WORLD! 42x", []wordSpan{{"hello", 0, 5}, {"world", 7, 12}, {"42x", 14, 17}}},
+		{"naïve café", []wordSpan{{"naïve", 0, 5}, {"café", 6, 10}}},
+		{"  Ça va?", []wordSpan{{"ça", 2, 4}, {"va", 5, 7}}},
+		{"?!... --", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := wordSpans(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wordSpans(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	s := newTokenizerService()
+	id := func(token string) int { return s.tokenizer.vocab[token] }
+
+	tests := []struct {
+		name    string
+		text    string
+		window  int
+		overlap int
+		texts   []string
+		tokens  []int // Tokens per chunk
+		total   int
+	}{
+		{
+			// Consecutive windows share two tokens and the last one is partial
+			name: "overlap", text: "one two three four five six seven", window: 4, overlap: 2,
+			texts:  []string{"one two three four", "three four five six", "five six seven"},
+			tokens: []int{4, 4, 3}, total: 7,
+		},
+		{
+			name: "exact fit", text: "one two three four", window: 4, overlap: 1,
+			texts:  []string{"one two three four"},
+			tokens: []int{4}, total: 4,
+		},
+		{
+			name: "last partial window", text: "one two three four five", window: 3, overlap: 1,
+			texts:  []string{"one two three", "three four five"},
+			tokens: []int{3, 3}, total: 5,
+		},
+		{
+			// A window that starts or ends inside a word covers the whole word
+			name: "word pieces", text: "playing one playing", window: 2, overlap: 1,
+			texts:  []string{"playing", "playing one", "one playing", "playing"},
+			tokens: []int{2, 2, 2, 2}, total: 5,
+		},
+		{
+			name: "multi-byte text", text: "Naïve café, naïve!", window: 2, overlap: 1,
+			texts:  []string{"Naïve café", "café, naïve"},
+			tokens: []int{2, 2}, total: 3,
+		},
+		{
+			name: "punctuation only", text: "?!... --", window: 4, overlap: 1,
+			texts:  []string{"?!... --"},
+			tokens: []int{0}, total: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, seqs, total := s.chunk(tt.text, tt.window, tt.overlap)
+			if total != tt.total {
+				t.Errorf("got %d tokens, want %d", total, tt.total)
+			}
+			if got := chunkTexts(chunks); !reflect.DeepEqual(got, tt.texts) {
+				t.Fatalf("got chunks %q, want %q", got, tt.texts)
+			}
+
+			runes := []rune(tt.text)
+			for i, c := range chunks {
+				if c.Index != i || c.Tokens != tt.tokens[i] {
+					t.Errorf("chunk %d has index %d and %d tokens, want %d tokens", i, c.Index, c.Tokens, tt.tokens[i])
+				}
+				if string(runes[c.Start:c.End]) != c.Text {
+					t.Errorf("chunk %d offsets %d-%d don't match its text %q", i, c.Start, c.End, c.Text)
+				}
+
+				seq := seqs[i]
+				if len(seq) != c.Tokens+2 || seq[0] != testCLS || seq[len(seq)-1] != testSEP {
+					t.Errorf("chunk %d has sequence %v, want %d tokens between [CLS] and [SEP]", i, seq, c.Tokens)
+				}
+			}
+		})
+	}
+
+	// The overlapping tokens are the same ids in both windows
+	_, seqs, _ := s.chunk("one two three four five", 3, 1)
+	want := [][]int{
+		{testCLS, id("one"), id("two"), id("three"), testSEP},
+		{testCLS, id("three"), id("four"), id("five"), testSEP},
+	}
+	if !reflect.DeepEqual(seqs, want) {
+		t.Errorf("got sequences %v, want %v", seqs, want)
+	}
+}
+
+func TestResolveDocumentOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts DocumentOptions
+		want DocumentOptions
+		err  bool
+	}{
+		{"defaults", DocumentOptions{}, DocumentOptions{ChunkTokens: 254, Overlap: 32, Pooling: PoolingMean}, false},
+		{"small window", DocumentOptions{ChunkTokens: 40}, DocumentOptions{ChunkTokens: 40, Overlap: 10, Pooling: PoolingMean}, false},
+		{"window above limit", DocumentOptions{ChunkTokens: 1000, Overlap: 8, Pooling: PoolingMax}, DocumentOptions{ChunkTokens: 254, Overlap: 8, Pooling: PoolingMax}, false},
+		{"overlap equals window", DocumentOptions{ChunkTokens: 16, Overlap: 16}, DocumentOptions{}, true},
+		{"overlap above window", DocumentOptions{ChunkTokens: 16, Overlap: 20}, DocumentOptions{}, true},
+		{"unknown pooling", DocumentOptions{Pooling: "median"}, DocumentOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveDocumentOptions(tt.opts, 254)
+			if tt.err {
+				if !errors.Is(err, ErrInvalidDocumentOptions) {
+					t.Errorf("got error %v, want ErrInvalidDocumentOptions", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
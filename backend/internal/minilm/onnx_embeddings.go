@@ -10,7 +10,6 @@ import (
 	"io"
 	"log"
 	"math"
-	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -20,6 +19,9 @@ import (
 	"time"
 	"unicode"
 
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/downloader"
+
 	ort "github.com/yalue/onnxruntime_go"
 )
 
@@ -32,6 +34,16 @@ type OnnxEmbeddingService struct {
 	tokenizer *wordPiece
 	session   *ort.DynamicAdvancedSession
 	maxLen    int
+	batchSize int
+
+	// run embeds one batch padded to seq tokens; it is runBatch except in
+	// tests that exercise batching without the model
+	run func(seqs [][]int, seq int) ([][]float32, error)
+
+	// runtimeHeld is set while the service holds a reference on the shared ONNX Runtime
+	runtimeHeld bool
+
+	downloader *downloader.Downloader
 }
 
 // Ensure OnnxEmbeddingService implements EmbeddingProvider
@@ -39,9 +51,16 @@ var _ EmbeddingProvider = (*OnnxEmbeddingService)(nil)
 
 // NewOnnxEmbeddingService creates a new ONNX-based embedding service
 func NewOnnxEmbeddingService(config *Config) *OnnxEmbeddingService {
-	return &OnnxEmbeddingService{
-		config: config,
-		maxLen: 128, // Standard max length for MiniLM
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	s := &OnnxEmbeddingService{
+		config:     config,
+		maxLen:     128, // Standard max length for MiniLM
+		batchSize:  batchSize,
+		downloader: downloader.NewDownloader(log.Default()),
 		info: &ServiceInfo{
 			Name:        "ONNX MiniLM Embeddings",
 			Version:     "2.0.0",
@@ -52,6 +71,8 @@ func NewOnnxEmbeddingService(config *Config) *OnnxEmbeddingService {
 			Metadata:    make(map[string]string),
 		},
 	}
+	s.run = s.runBatch
+	return s
 }
 
 // Initialize initializes the ONNX embeddings service
@@ -62,7 +83,7 @@ func (s *OnnxEmbeddingService) Initialize(ctx context.Context) error {
 	log.Println("Initializing ONNX embeddings service with pure Go tokenizer...")
 
 	// Ensure runtime and model files
-	if err := s.ensureRuntimeAndModel(); err != nil {
+	if err := s.ensureRuntimeAndModel(ctx); err != nil {
 		return fmt.Errorf("failed to ensure runtime and model: %w", err)
 	}
 
@@ -81,23 +102,22 @@ func (s *OnnxEmbeddingService) Initialize(ctx context.Context) error {
 	return nil
 }
 
-func (s *OnnxEmbeddingService) ensureRuntimeAndModel() error {
+func (s *OnnxEmbeddingService) ensureRuntimeAndModel(ctx context.Context) error {
 	// Ensure model directory
 	if err := os.MkdirAll(s.config.ModelPath, 0o755); err != nil {
 		return err
 	}
 
-	// Download ORT shared library
-	libPath, err := ensureORTSharedLib()
-	if err != nil {
-		return fmt.Errorf("onnxruntime lib: %w", err)
+	// Download ORT shared library and set up the environment
+	if !s.runtimeHeld {
+		if err := AcquireRuntime(ctx, s.downloader); err != nil {
+			return fmt.Errorf("onnxruntime: %w", err)
+		}
+		s.runtimeHeld = true
 	}
 
-	// Point onnxruntime_go to the shared library
-	ort.SetSharedLibraryPath(libPath)
-
 	// Download model and vocab
-	_, vocabPath, err := ensureMiniLMModel(s.config.ModelPath)
+	_, vocabPath, err := ensureMiniLMModel(ctx, s.downloader, s.ModelFiles())
 	if err != nil {
 		return err
 	}
@@ -112,10 +132,6 @@ func (s *OnnxEmbeddingService) ensureRuntimeAndModel() error {
 }
 
 func (s *OnnxEmbeddingService) initSession() error {
-	if err := ort.InitializeEnvironment(); err != nil {
-		return err
-	}
-
 	// Input and output names we expect
 	inNames := []string{"input_ids", "attention_mask", "token_type_ids"}
 	outNames := []string{"last_hidden_state"}
@@ -129,6 +145,38 @@ func (s *OnnxEmbeddingService) initSession() error {
 	return nil
 }
 
+// Reload recreates the ONNX session so a newly installed model is picked up
+// without restarting the backend
+func (s *OnnxEmbeddingService) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Println("Reloading ONNX embeddings model...")
+
+	if s.session != nil {
+		s.session.Destroy()
+		s.session = nil
+	}
+	s.ready = false
+
+	if err := s.ensureRuntimeAndModel(ctx); err != nil {
+		s.info.Status = "error"
+		return fmt.Errorf("failed to ensure runtime and model: %w", err)
+	}
+
+	if err := s.initSession(); err != nil {
+		s.info.Status = "error"
+		return fmt.Errorf("failed to initialize session: %w", err)
+	}
+
+	s.ready = true
+	s.info.Status = "ready"
+	s.info.LastUpdated = time.Now()
+
+	log.Println("ONNX embeddings model reloaded")
+	return nil
+}
+
 // IsReady returns true if the service is ready
 func (s *OnnxEmbeddingService) IsReady() bool {
 	s.mu.RLock()
@@ -176,17 +224,63 @@ func (s *OnnxEmbeddingService) GenerateEmbeddings(ctx context.Context, texts []s
 	}
 
 	// Tokenize all texts
-	ids, masks := s.batchTokenize(texts, s.maxLen)
+	seqs := make([][]int, len(texts))
+	for i, text := range texts {
+		seqs[i] = s.encode(text, s.maxLen)
+	}
+
+	return s.embedSequences(ctx, seqs)
+}
+
+// embedSequences runs the model on token sequences that already include
+// [CLS] and [SEP] and returns their mean-pooled, normalized embeddings in the
+// same order. Sequences are sorted by length and run in micro-batches of at
+// most batchSize, each padded only to its longest sequence, so short texts
+// don't pay for attention over padding and large requests don't build one
+// huge tensor.
+func (s *OnnxEmbeddingService) embedSequences(ctx context.Context, seqs [][]int) ([][]float32, error) {
+	order := make([]int, len(seqs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return len(seqs[order[i]]) < len(seqs[order[j]]) })
+
+	out := make([][]float32, len(seqs))
+	batch := make([][]int, 0, s.batchSize)
+	for start := 0; start < len(order); start += s.batchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := min(start+s.batchSize, len(order))
+		batch = batch[:0]
+		for _, i := range order[start:end] {
+			batch = append(batch, seqs[i])
+		}
 
-	// Create tensors
-	bsz := len(texts)
-	seq := s.maxLen
+		// Sorted ascending, so the last sequence is the longest
+		embeddings, err := s.run(batch, len(batch[len(batch)-1]))
+		if err != nil {
+			return nil, err
+		}
+		for k, i := range order[start:end] {
+			out[i] = embeddings[k]
+		}
+	}
+	return out, nil
+}
+
+// runBatch runs the model on one batch of sequences padded to seq tokens
+func (s *OnnxEmbeddingService) runBatch(seqs [][]int, seq int) ([][]float32, error) {
+	bsz := len(seqs)
 	inputIDs := make([]int64, bsz*seq)
 	attMask := make([]int64, bsz*seq)
 
-	for i := 0; i < bsz; i++ {
-		copy(inputIDs[i*seq:(i+1)*seq], ids[i])
-		copy(attMask[i*seq:(i+1)*seq], masks[i])
+	for i, ids := range seqs {
+		for j, id := range ids {
+			inputIDs[i*seq+j] = int64(id)
+			attMask[i*seq+j] = 1
+		}
 	}
 
 	in1, err := ort.NewTensor[int64](ort.NewShape(int64(bsz), int64(seq)), inputIDs)
@@ -216,8 +310,9 @@ func (s *OnnxEmbeddingService) GenerateEmbeddings(ctx context.Context, texts []s
 		return nil, fmt.Errorf("ONNX inference failed: %w", err)
 	}
 
-	// Process output
+	// Process output; ORT allocated it, so release it once pooled
 	out0 := outputsVals[0]
+	defer out0.Destroy()
 	t, ok := out0.(*ort.Tensor[float32])
 	if !ok {
 		return nil, errors.New("unexpected output type")
@@ -270,39 +365,23 @@ func (s *OnnxEmbeddingService) GenerateEmbeddings(ctx context.Context, texts []s
 	return out, nil
 }
 
-// batchTokenize tokenizes multiple texts
-func (s *OnnxEmbeddingService) batchTokenize(texts []string, maxLen int) ([][]int64, [][]int64) {
-	ids := make([][]int64, len(texts))
-	masks := make([][]int64, len(texts))
-	for i, t := range texts {
-		ii, mm := s.encode(t, maxLen)
-		ids[i], masks[i] = ii, mm
+// encode returns the [CLS] ... [SEP] token sequence of a text, truncated to maxLen
+func (s *OnnxEmbeddingService) encode(text string, maxLen int) []int {
+	seq := []int{s.tokenizer.clsID}
+	seq = append(seq, s.tokenize(text)...)
+	if len(seq) >= maxLen {
+		seq = seq[:maxLen-1]
 	}
-	return ids, masks
+	return append(seq, s.tokenizer.sepID)
 }
 
-func (s *OnnxEmbeddingService) encode(text string, maxLen int) ([]int64, []int64) {
-	toks := basicTokens(text)
+// tokenize returns the WordPiece tokens of a text
+func (s *OnnxEmbeddingService) tokenize(text string) []int {
 	var pieces []int
-	for _, w := range toks {
+	for _, w := range basicTokens(text) {
 		pieces = append(pieces, s.tokenizer.tokenizeWord(w)...)
 	}
-	seq := []int{s.tokenizer.clsID}
-	seq = append(seq, pieces...)
-	seq = append(seq, s.tokenizer.sepID)
-	if len(seq) > maxLen {
-		seq = seq[:maxLen]
-	}
-	ids := make([]int64, maxLen)
-	mask := make([]int64, maxLen)
-	for i, v := range seq {
-		ids[i] = int64(v)
-		mask[i] = 1
-	}
-	for i := len(seq); i < maxLen; i++ {
-		ids[i] = 0
-	}
-	return ids, mask
+	return pieces
 }
 
 // ComputeSimilarity computes cosine similarity between two embeddings
@@ -379,8 +458,11 @@ func (s *OnnxEmbeddingService) Shutdown(ctx context.Context) error {
 		s.session = nil
 	}
 
-	// Clean up ONNX Runtime environment
-	ort.DestroyEnvironment()
+	// Clean up ONNX Runtime environment once no other service uses it
+	if s.runtimeHeld {
+		ReleaseRuntime()
+		s.runtimeHeld = false
+	}
 
 	s.ready = false
 	s.info.Status = "stopped"
@@ -392,143 +474,86 @@ func (s *OnnxEmbeddingService) Shutdown(ctx context.Context) error {
 
 // Downloads and model management (adapted from GoLLMCore)
 
-func ensureMiniLMModel(dir string) (modelPath, vocabPath string, err error) {
-	modelPath = filepath.Join(dir, "model.onnx")
-	vocabPath = filepath.Join(dir, "vocab.txt")
-
-	if _, e := os.Stat(modelPath); e != nil {
-		urls := []string{
-			// ONNX export of MiniLM (Transformers.js format)
-			"https://huggingface.co/Xenova/all-MiniLM-L6-v2/resolve/main/onnx/model.onnx",
-			// Alternate path (some mirrors place model at root)
-			"https://huggingface.co/Xenova/all-MiniLM-L6-v2/resolve/main/model.onnx",
-			// Community ONNX mirrors
-			"https://huggingface.co/onnx-community/all-MiniLM-L6-v2/resolve/main/model.onnx",
-		}
-		if err = tryDownload(urls, modelPath, 3, 180*time.Second); err != nil {
-			return "", "", err
-		}
+// ModelFiles returns the files that make up the MiniLM model
+func (s *OnnxEmbeddingService) ModelFiles() []downloader.File {
+	model, ok := catalog.Default().DefaultModel("embeddings")
+	if !ok {
+		return nil
 	}
 
-	if _, e := os.Stat(vocabPath); e != nil {
-		urls := []string{
-			"https://huggingface.co/sentence-transformers/all-MiniLM-L6-v2/resolve/main/vocab.txt",
-		}
-		if err = tryDownload(urls, vocabPath, 3, 60*time.Second); err != nil {
+	files := make([]downloader.File, 0, len(model.Files))
+	for _, artifact := range model.Files {
+		files = append(files, artifact.Download(s.config.ModelPath))
+	}
+	return files
+}
+
+func ensureMiniLMModel(ctx context.Context, d *downloader.Downloader, files []downloader.File) (modelPath, vocabPath string, err error) {
+	for _, file := range files {
+		// Fetch keeps an existing file only if it passes verification
+		if err = d.Fetch(ctx, file, nil); err != nil {
 			return "", "", err
 		}
+
+		switch filepath.Base(file.DestPath) {
+		case "model.onnx":
+			modelPath = file.DestPath
+		case "vocab.txt":
+			vocabPath = file.DestPath
+		}
 	}
 
+	if modelPath == "" || vocabPath == "" {
+		return "", "", fmt.Errorf("model catalog entry must provide model.onnx and vocab.txt")
+	}
 	return modelPath, vocabPath, nil
 }
 
-func ensureORTSharedLib() (string, error) {
+func ensureORTSharedLib(ctx context.Context, d *downloader.Downloader) (string, error) {
+	binary, ok := catalog.Default().Binary("onnxruntime")
+	if !ok {
+		return "", fmt.Errorf("onnxruntime missing from model catalog")
+	}
+	artifact, ok := binary.ForPlatform(runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return "", fmt.Errorf("unsupported platform for ORT: %s", runtime.GOOS)
+	}
+
 	baseDir := filepath.Join(os.TempDir(), "onnxruntime")
-	ortVersion := "v1.22.0"
-	versionDir := filepath.Join(baseDir, ortVersion)
+	versionDir := filepath.Join(baseDir, binary.Version)
 	if err := os.MkdirAll(versionDir, 0o755); err != nil {
 		return "", err
 	}
 
+	var libName string
 	switch runtime.GOOS {
 	case "windows":
-		dll := filepath.Join(versionDir, "onnxruntime.dll")
-		if fileExists(dll) {
-			return dll, nil
-		}
-		urls := []string{
-			"https://github.com/microsoft/onnxruntime/releases/download/" + ortVersion + "/onnxruntime-win-x64-" + strings.TrimPrefix(ortVersion, "v") + ".zip",
-		}
-		zipPath := filepath.Join(versionDir, "ort.zip")
-		if err := tryDownload(urls, zipPath, 3, 240*time.Second); err != nil {
-			return "", err
-		}
-		if err := unzipOne(zipPath, versionDir, "onnxruntime.dll"); err != nil {
-			return "", err
-		}
-		return dll, nil
-
+		libName = "onnxruntime.dll"
 	case "darwin":
-		dylib := filepath.Join(versionDir, "libonnxruntime.dylib")
-		if fileExists(dylib) {
-			return dylib, nil
-		}
 		// arm64 vs x64 both extract libonnxruntime.dylib
-		urls := []string{
-			"https://github.com/microsoft/onnxruntime/releases/download/" + ortVersion + "/onnxruntime-osx-universal2-" + strings.TrimPrefix(ortVersion, "v") + ".tgz",
-			"https://github.com/microsoft/onnxruntime/releases/download/" + ortVersion + "/onnxruntime-osx-arm64-" + strings.TrimPrefix(ortVersion, "v") + ".tgz",
-			"https://github.com/microsoft/onnxruntime/releases/download/" + ortVersion + "/onnxruntime-osx-x64-" + strings.TrimPrefix(ortVersion, "v") + ".tgz",
-		}
-		tgz := filepath.Join(versionDir, "ort.tgz")
-		if err := tryDownload(urls, tgz, 3, 240*time.Second); err != nil {
-			return "", err
-		}
-		if err := untarSelect(tgz, versionDir, []string{"libonnxruntime.dylib"}); err != nil {
-			return "", err
-		}
-		return dylib, nil
-
-	case "linux":
-		so := filepath.Join(versionDir, "libonnxruntime.so")
-		if fileExists(so) {
-			return so, nil
-		}
-		urls := []string{
-			"https://github.com/microsoft/onnxruntime/releases/download/" + ortVersion + "/onnxruntime-linux-x64-" + strings.TrimPrefix(ortVersion, "v") + ".tgz",
-		}
-		tgz := filepath.Join(versionDir, "ort.tgz")
-		if err := tryDownload(urls, tgz, 3, 240*time.Second); err != nil {
-			return "", err
-		}
-		if err := untarSelect(tgz, versionDir, []string{"libonnxruntime.so"}); err != nil {
-			return "", err
-		}
-		return so, nil
-
+		libName = "libonnxruntime.dylib"
 	default:
-		return "", fmt.Errorf("unsupported platform for ORT: %s", runtime.GOOS)
+		libName = "libonnxruntime.so"
 	}
-}
 
-func tryDownload(urls []string, dst string, retries int, timeout time.Duration) error {
-	var last error
-	for i, u := range urls {
-		log.Printf("Downloading: %s (%d/%d)", u, i+1, len(urls))
-		if err := downloadFile(u, dst, timeout); err != nil {
-			last = err
-			continue
-		}
-		return nil
+	libPath := filepath.Join(versionDir, libName)
+	if fileExists(libPath) {
+		return libPath, nil
 	}
-	return last
-}
 
-func downloadFile(url, dst string, timeout time.Duration) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("User-Agent", "AliceAI/1.0")
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("bad status: %s", resp.Status)
-	}
-	tmp := dst + ".part"
-	out, err := os.Create(tmp)
-	if err != nil {
-		return err
+	archive := artifact.Download(versionDir)
+	if err := d.Fetch(ctx, archive, nil); err != nil {
+		return "", err
 	}
-	if _, err := io.Copy(out, resp.Body); err != nil {
-		out.Close()
-		return err
+
+	if strings.HasSuffix(archive.DestPath, ".zip") {
+		if err := unzipOne(archive.DestPath, versionDir, libName); err != nil {
+			return "", err
+		}
+	} else if err := untarSelect(archive.DestPath, versionDir, []string{libName}); err != nil {
+		return "", err
 	}
-	out.Close()
-	return os.Rename(tmp, dst)
+	return libPath, nil
 }
 
 func fileExists(p string) bool {
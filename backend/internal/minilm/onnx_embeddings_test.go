@@ -0,0 +1,188 @@
+package minilm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+// benchmarkTexts returns n texts shaped like an assistant's workload: mostly
+// short queries and notes, with the occasional long paragraph
+func benchmarkTexts(n int) []string {
+	words := strings.Fields(`the quick brown fox jumps over a lazy dog while remind me
+		to call mom tomorrow about dinner plans and what is the weather like in
+		paris next week please summarize this meeting note for the project team`)
+
+	rng := rand.New(rand.NewSource(1))
+	texts := make([]string, n)
+	for i := range texts {
+		length := 4 + rng.Intn(12)
+		if rng.Intn(10) == 0 {
+			length = 60 + rng.Intn(100)
+		}
+		text := make([]string, length)
+		for j := range text {
+			text[j] = words[rng.Intn(len(words))]
+		}
+		texts[i] = strings.Join(text, " ")
+	}
+	return texts
+}
+
+// newTestService initializes the embeddings service from MINILM_MODEL_PATH,
+// skipping the test or benchmark when the model isn't installed
+func newTestService(tb testing.TB) *OnnxEmbeddingService {
+	tb.Helper()
+
+	modelPath := os.Getenv("MINILM_MODEL_PATH")
+	if modelPath == "" {
+		tb.Skip("MINILM_MODEL_PATH is not set")
+	}
+
+	ctx := context.Background()
+	s := NewOnnxEmbeddingService(&Config{ModelPath: modelPath, Dimension: 384, BatchSize: 8})
+	if err := s.Initialize(ctx); err != nil {
+		tb.Skipf("embeddings service unavailable: %v", err)
+	}
+	tb.Cleanup(func() { s.Shutdown(ctx) })
+	return s
+}
+
+// TestEmbedSequencesOrder checks that bucketed micro-batches return the same
+// embeddings, in the same order, as one batch padded to the maximum length
+func TestEmbedSequencesOrder(t *testing.T) {
+	s := newTestService(t)
+
+	texts := benchmarkTexts(50)
+	seqs := make([][]int, len(texts))
+	for i, text := range texts {
+		seqs[i] = s.encode(text, s.maxLen)
+	}
+
+	want, err := s.runBatch(seqs, s.maxLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.embedSequences(context.Background(), seqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range want {
+		var similarity float32
+		for d := range want[i] {
+			similarity += want[i][d] * got[i][d]
+		}
+		if similarity < 0.999 {
+			t.Errorf("text %d: bucketed embedding differs from padded one (similarity %.4f)", i, similarity)
+		}
+	}
+}
+
+// TestEmbedSequencesBatching checks the sorting, micro-batching and order
+// restoring of embedSequences with a stand-in for the model that embeds each
+// sequence as its own first token
+func TestEmbedSequencesBatching(t *testing.T) {
+	s := NewOnnxEmbeddingService(&Config{BatchSize: 4})
+
+	rng := rand.New(rand.NewSource(2))
+	seqs := make([][]int, 11)
+	for i := range seqs {
+		seqs[i] = make([]int, 1+rng.Intn(20))
+		seqs[i][0] = i
+	}
+
+	var batches [][]int // Lengths of the sequences in each batch
+	s.run = func(batch [][]int, seq int) ([][]float32, error) {
+		lengths := make([]int, len(batch))
+		out := make([][]float32, len(batch))
+		for k, ids := range batch {
+			lengths[k] = len(ids)
+			out[k] = []float32{float32(ids[0])}
+			if len(ids) > seq {
+				t.Errorf("sequence of %d tokens in a batch padded to %d", len(ids), seq)
+			}
+		}
+		if seq != lengths[len(lengths)-1] {
+			t.Errorf("batch %v padded to %d, want its longest sequence", lengths, seq)
+		}
+		batches = append(batches, lengths)
+		return out, nil
+	}
+
+	out, err := s.embedSequences(context.Background(), seqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, embedding := range out {
+		if int(embedding[0]) != i {
+			t.Errorf("result %d holds the embedding of sequence %d", i, int(embedding[0]))
+		}
+	}
+
+	if len(batches) != 3 {
+		t.Fatalf("got %d batches %v, want 3", len(batches), batches)
+	}
+	previous := 0
+	for _, lengths := range batches {
+		if len(lengths) > s.batchSize {
+			t.Errorf("batch %v is larger than %d", lengths, s.batchSize)
+		}
+		for _, length := range lengths {
+			if length < previous {
+				t.Fatalf("batches %v are not sorted by length", batches)
+			}
+			previous = length
+		}
+	}
+
+	// Errors from the model and cancellation stop the request
+	failure := errors.New("inference failed")
+	s.run = func([][]int, int) ([][]float32, error) { return nil, failure }
+	if _, err := s.embedSequences(context.Background(), seqs); !errors.Is(err, failure) {
+		t.Errorf("got error %v, want %v", err, failure)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.embedSequences(ctx, seqs); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v for a cancelled request, want context.Canceled", err)
+	}
+}
+
+// BenchmarkGenerateEmbeddings compares running a whole request as one batch
+// padded to the model's maximum length with length-bucketed micro-batches
+// padded to their longest text. It needs the MiniLM model, so it only runs
+// when MINILM_MODEL_PATH points at an installed copy.
+func BenchmarkGenerateEmbeddings(b *testing.B) {
+	ctx := context.Background()
+	s := newTestService(b)
+	s.batchSize = DefaultBatchSize
+
+	texts := benchmarkTexts(256)
+	seqs := make([][]int, len(texts))
+	for i, text := range texts {
+		seqs[i] = s.encode(text, s.maxLen)
+	}
+
+	b.Run("padded-single-batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.runBatch(seqs, s.maxLen); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(b.N*len(texts))/b.Elapsed().Seconds(), "texts/s")
+	})
+
+	b.Run("bucketed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.embedSequences(ctx, seqs); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(b.N*len(texts))/b.Elapsed().Seconds(), "texts/s")
+	})
+}
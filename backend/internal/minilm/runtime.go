@@ -0,0 +1,56 @@
+package minilm
+
+import (
+	"context"
+	"sync"
+
+	"alice-backend/internal/downloader"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// The ONNX Runtime environment is process-wide, so services using it share a
+// reference count and the environment is only destroyed by the last one
+var (
+	runtimeMu    sync.Mutex
+	runtimeUsers int
+)
+
+// AcquireRuntime downloads the ONNX Runtime shared library if needed and
+// initializes the environment. Each successful call must be paired with a
+// call to ReleaseRuntime.
+func AcquireRuntime(ctx context.Context, d *downloader.Downloader) error {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if !ort.IsInitialized() {
+		libPath, err := ensureORTSharedLib(ctx, d)
+		if err != nil {
+			return err
+		}
+
+		// Point onnxruntime_go to the shared library
+		ort.SetSharedLibraryPath(libPath)
+		if err := ort.InitializeEnvironment(); err != nil {
+			return err
+		}
+	}
+
+	runtimeUsers++
+	return nil
+}
+
+// ReleaseRuntime drops a reference taken by AcquireRuntime and destroys the
+// environment when it was the last one
+func ReleaseRuntime() {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if runtimeUsers == 0 {
+		return
+	}
+	runtimeUsers--
+	if runtimeUsers == 0 && ort.IsInitialized() {
+		ort.DestroyEnvironment()
+	}
+}
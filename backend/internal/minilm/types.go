@@ -5,10 +5,14 @@ import (
 	"time"
 )
 
+// DefaultBatchSize is the number of texts run through the model at once
+const DefaultBatchSize = 32
+
 // Config holds embeddings configuration
 type Config struct {
 	ModelPath string
 	Dimension int
+	BatchSize int // Texts per model run; defaults to DefaultBatchSize
 }
 
 // ServiceInfo contains information about the embeddings service
@@ -39,6 +43,9 @@ type EmbeddingProvider interface {
 	// GenerateEmbeddings generates multiple embeddings
 	GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
 
+	// EmbedDocument embeds a text of any length as overlapping chunks
+	EmbedDocument(ctx context.Context, text string, opts DocumentOptions) (*DocumentEmbedding, error)
+
 	// ComputeSimilarity computes cosine similarity between two embeddings
 	ComputeSimilarity(ctx context.Context, embedding1, embedding2 []float32) (float32, error)
 
@@ -0,0 +1,355 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"alice-backend/internal/downloader"
+	"alice-backend/internal/events"
+)
+
+// jobEventInterval limits how often progress updates of a job are published
+const jobEventInterval = 250 * time.Millisecond
+
+// Download job states
+const (
+	DownloadStatusPending     = "pending"
+	DownloadStatusDownloading = "downloading"
+	DownloadStatusInstalling  = "installing"
+	DownloadStatusCompleted   = "completed"
+	DownloadStatusFailed      = "failed"
+)
+
+// Service names accepted by the download manager
+const (
+	ServiceSTT        = "stt"
+	ServiceTTS        = "tts"
+	ServiceEmbeddings = "embeddings"
+	ServiceWakeWord   = "wakeword"
+)
+
+var (
+	// ErrUnknownService is returned for a service name the manager does not know
+	ErrUnknownService = errors.New("unknown service")
+
+	// ErrServiceDisabled is returned when the target service is not running
+	ErrServiceDisabled = errors.New("service is not enabled")
+
+	// ErrDownloadInProgress is returned when a download for the service is already running
+	ErrDownloadInProgress = errors.New("download already in progress")
+)
+
+// DownloadJob tracks a background model download
+type DownloadJob struct {
+	Service         string     `json:"service"`
+	Model           string     `json:"model"`
+	Status          string     `json:"status"`
+	BytesDownloaded int64      `json:"bytes_downloaded"`
+	TotalBytes      int64      `json:"total_bytes"`
+	SpeedBps        float64    `json:"speed_bps"`
+	Error           string     `json:"error,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+
+	lastPublished time.Time
+}
+
+// ServiceStatus is the payload of service readiness events
+type ServiceStatus struct {
+	Ready bool `json:"ready"`
+}
+
+// Active returns true while the job is still downloading or installing
+func (j *DownloadJob) Active() bool {
+	switch j.Status {
+	case DownloadStatusPending, DownloadStatusDownloading, DownloadStatusInstalling:
+		return true
+	}
+	return false
+}
+
+// Progress returns the completed fraction of the download in percent
+func (j *DownloadJob) Progress() float64 {
+	if j.Status == DownloadStatusCompleted {
+		return 100
+	}
+	if j.TotalBytes <= 0 {
+		return 0
+	}
+	progress := float64(j.BytesDownloaded) * 100 / float64(j.TotalBytes)
+	if progress > 100 {
+		progress = 100
+	}
+	return progress
+}
+
+// StartDownload starts downloading the model of a service in the background.
+// For the TTS service, model selects the voice; it defaults to the current default voice.
+// For the STT service, model selects a Whisper model; it defaults to the active one.
+func (m *Manager) StartDownload(service, model string) (*DownloadJob, error) {
+	files, model, err := m.downloadFiles(service, model)
+	if err != nil {
+		return nil, err
+	}
+
+	m.jobsMu.Lock()
+	defer m.jobsMu.Unlock()
+
+	if job, exists := m.jobs[service]; exists && job.Active() {
+		return nil, fmt.Errorf("%w for %s", ErrDownloadInProgress, service)
+	}
+
+	job := &DownloadJob{
+		Service:   service,
+		Model:     model,
+		Status:    DownloadStatusPending,
+		StartedAt: time.Now(),
+	}
+	m.jobs[service] = job
+	m.publishJob(job)
+
+	m.jobsWG.Add(1)
+	go m.runDownload(job, files)
+
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// GetDownloadJob returns a snapshot of the most recent download job of a service
+func (m *Manager) GetDownloadJob(service string) (*DownloadJob, bool) {
+	m.jobsMu.Lock()
+	defer m.jobsMu.Unlock()
+
+	job, exists := m.jobs[service]
+	if !exists {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+// IsModelInstalled reports whether the model files of a service are present and the service is ready
+func (m *Manager) IsModelInstalled(service string) bool {
+	switch service {
+	case ServiceSTT:
+		stt := m.GetSTTService()
+		return stt != nil && stt.IsReady() && filesExist(stt.ModelFiles())
+	case ServiceTTS:
+		tts := m.GetTTSService()
+		return tts != nil && tts.IsReady() && tts.IsVoiceInstalled(tts.GetDefaultVoice())
+	case ServiceEmbeddings:
+		embeddings := m.GetEmbeddingService()
+		return embeddings != nil && embeddings.IsReady()
+	case ServiceWakeWord:
+		wakeWord := m.GetWakeWordService()
+		return wakeWord != nil && wakeWord.IsReady()
+	}
+	return false
+}
+
+// downloadFiles resolves the files to fetch for a service and the model name they belong to
+func (m *Manager) downloadFiles(service, model string) ([]downloader.File, string, error) {
+	switch service {
+	case ServiceSTT:
+		stt := m.GetSTTService()
+		if stt == nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrServiceDisabled, service)
+		}
+		if model == "" {
+			model = stt.ActiveModel()
+		}
+		files, err := stt.ModelFilesFor(model)
+		if err != nil {
+			return nil, "", err
+		}
+		return files, model, nil
+
+	case ServiceTTS:
+		tts := m.GetTTSService()
+		if tts == nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrServiceDisabled, service)
+		}
+		if model == "" {
+			model = tts.GetDefaultVoice()
+		}
+		files, err := tts.VoiceFiles(model)
+		if err != nil {
+			return nil, "", err
+		}
+		return files, model, nil
+
+	case ServiceEmbeddings:
+		embeddings := m.GetEmbeddingService()
+		if embeddings == nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrServiceDisabled, service)
+		}
+		return embeddings.ModelFiles(), embeddings.GetInfo().Model, nil
+
+	case ServiceWakeWord:
+		wakeWord := m.GetWakeWordService()
+		if wakeWord == nil {
+			return nil, "", fmt.Errorf("%w: %s", ErrServiceDisabled, service)
+		}
+		files := wakeWord.ModelFiles()
+		if len(files) == 0 {
+			return nil, "", fmt.Errorf("no %s model in model catalog", service)
+		}
+		return files, filepath.Base(files[len(files)-1].DestPath), nil
+	}
+
+	return nil, "", fmt.Errorf("%w: %s", ErrUnknownService, service)
+}
+
+// runDownload fetches all missing files of a job and hot-plugs the result into the service
+func (m *Manager) runDownload(job *DownloadJob, files []downloader.File) {
+	defer m.jobsWG.Done()
+
+	ctx := m.jobsCtx
+	log.Printf("Starting %s model download: %s", job.Service, job.Model)
+
+	// Work out the total size up front so progress is meaningful across files
+	var pending []downloader.File
+	var totalBytes int64
+	for _, file := range files {
+		if _, err := os.Stat(file.DestPath); err == nil {
+			// Only keep an existing file if it matches its known checksum and size
+			err := m.downloader.Verify(file)
+			if err == nil {
+				continue
+			}
+			log.Printf("Existing file %s failed verification, downloading again: %v", file.DestPath, err)
+			os.Remove(file.DestPath)
+		}
+		pending = append(pending, file)
+		if size := m.downloader.ContentLength(ctx, file.URLs[0]); size > 0 {
+			totalBytes += size
+		}
+	}
+
+	m.updateJob(job, func(j *DownloadJob) {
+		j.Status = DownloadStatusDownloading
+		j.TotalBytes = totalBytes
+	})
+
+	var completedBytes int64
+	for _, file := range pending {
+		written, err := m.downloadFile(ctx, job, file, completedBytes)
+		if err != nil {
+			m.failJob(job, err)
+			return
+		}
+		completedBytes += written
+	}
+
+	m.updateJob(job, func(j *DownloadJob) {
+		j.Status = DownloadStatusInstalling
+		j.BytesDownloaded = completedBytes
+		if j.TotalBytes < completedBytes {
+			j.TotalBytes = completedBytes
+		}
+	})
+
+	if err := m.installModel(ctx, job.Service, job.Model); err != nil {
+		publishServiceStatus(job.Service, m.IsModelInstalled(job.Service))
+		m.failJob(job, fmt.Errorf("failed to load downloaded model: %w", err))
+		return
+	}
+	publishServiceStatus(job.Service, m.IsModelInstalled(job.Service))
+
+	m.updateJob(job, func(j *DownloadJob) {
+		now := time.Now()
+		j.Status = DownloadStatusCompleted
+		j.CompletedAt = &now
+	})
+	log.Printf("%s model download completed: %s", job.Service, job.Model)
+}
+
+// downloadFile fetches a single file and returns its size
+func (m *Manager) downloadFile(ctx context.Context, job *DownloadJob, file downloader.File, offset int64) (int64, error) {
+	var written int64
+	err := m.downloader.Fetch(ctx, file, func(received, total int64) {
+		written = received
+		m.updateJob(job, func(j *DownloadJob) {
+			j.BytesDownloaded = offset + received
+			if elapsed := time.Since(j.StartedAt).Seconds(); elapsed > 0 {
+				j.SpeedBps = float64(j.BytesDownloaded) / elapsed
+			}
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// installModel hot-plugs a freshly downloaded model into the running service
+func (m *Manager) installModel(ctx context.Context, service, model string) error {
+	switch service {
+	case ServiceSTT:
+		// Other Whisper models are only loaded once they are switched to
+		stt := m.GetSTTService()
+		if model != stt.ActiveModel() {
+			return nil
+		}
+		return stt.ReloadModel(ctx)
+	case ServiceTTS:
+		m.GetTTSService().ReloadVoices()
+		return nil
+	case ServiceEmbeddings:
+		return m.GetEmbeddingService().Reload(ctx)
+	case ServiceWakeWord:
+		return m.GetWakeWordService().Reload(ctx)
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownService, service)
+}
+
+// updateJob applies an update to a job and publishes it. Status changes are
+// always published, progress updates at most every jobEventInterval.
+func (m *Manager) updateJob(job *DownloadJob, update func(j *DownloadJob)) {
+	m.jobsMu.Lock()
+	defer m.jobsMu.Unlock()
+
+	status := job.Status
+	update(job)
+
+	if job.Status != status || time.Since(job.lastPublished) >= jobEventInterval {
+		m.publishJob(job)
+	}
+}
+
+// publishJob publishes a snapshot of a job; callers must hold jobsMu
+func (m *Manager) publishJob(job *DownloadJob) {
+	job.lastPublished = time.Now()
+
+	snapshot := *job
+	events.Publish(events.TypeJobUpdated, job.Service, snapshot)
+}
+
+// publishServiceStatus announces a change in a service's readiness
+func publishServiceStatus(service string, ready bool) {
+	events.Publish(events.TypeServiceStatus, service, ServiceStatus{Ready: ready})
+}
+
+func (m *Manager) failJob(job *DownloadJob, err error) {
+	log.Printf("%s model download failed: %v", job.Service, err)
+	m.updateJob(job, func(j *DownloadJob) {
+		now := time.Now()
+		j.Status = DownloadStatusFailed
+		j.Error = err.Error()
+		j.CompletedAt = &now
+	})
+}
+
+func filesExist(files []downloader.File) bool {
+	for _, file := range files {
+		if _, err := os.Stat(file.DestPath); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -8,10 +8,13 @@ import (
 	"sync"
 
 	"alice-backend/internal/config"
+	"alice-backend/internal/downloader"
 	grpcPiper "alice-backend/internal/grpc/piper"
 	grpcWhisper "alice-backend/internal/grpc/whisper"
 	"alice-backend/internal/minilm"
 	"alice-backend/internal/piper"
+	"alice-backend/internal/vectorstore"
+	"alice-backend/internal/wakeword"
 	"alice-backend/internal/whisper"
 )
 
@@ -21,15 +24,31 @@ type Manager struct {
 	sttService        *whisper.STTService
 	ttsService        *piper.TTSService
 	embeddingService  *minilm.OnnxEmbeddingService
+	wakeWordService   *wakeword.Service
+	vectorStore       *vectorstore.Store
 	whisperGRPCClient *grpcWhisper.Client
 	piperGRPCClient   *grpcPiper.Client
 	mu                sync.RWMutex
+
+	// Background model downloads
+	downloader *downloader.Downloader
+	jobs       map[string]*DownloadJob
+	jobsMu     sync.Mutex
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+	jobsWG     sync.WaitGroup
 }
 
 // NewManager creates a new model manager
 func NewManager(config *config.Config) *Manager {
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+
 	return &Manager{
-		config: config,
+		config:     config,
+		downloader: downloader.NewDownloader(log.Default()),
+		jobs:       make(map[string]*DownloadJob),
+		jobsCtx:    jobsCtx,
+		cancelJobs: cancelJobs,
 	}
 }
 
@@ -45,9 +64,12 @@ func (m *Manager) Initialize(ctx context.Context) error {
 		log.Println("Initializing STT service...")
 		sttConfig := &whisper.Config{
 			Language:       "en",
-			ModelPath:      "models/whisper-base.bin",
+			ModelPath:      m.config.Models.Whisper.Path,
+			Model:          m.config.Models.Whisper.Model,
 			SampleRate:     16000,
 			VoiceThreshold: 0.02,
+			DisableServer:  os.Getenv("WHISPER_MANAGED_SERVER") == "false",
+			DisableVAD:     os.Getenv("WHISPER_VAD") == "false",
 		}
 
 		m.sttService = whisper.NewSTTService(sttConfig)
@@ -96,6 +118,7 @@ func (m *Manager) Initialize(ctx context.Context) error {
 			return fmt.Errorf("failed to initialize STT service: %w", err)
 		}
 		log.Println("STT service initialized")
+		publishServiceStatus(ServiceSTT, true)
 	}
 
 	// Initialize TTS service if enabled
@@ -136,6 +159,7 @@ func (m *Manager) Initialize(ctx context.Context) error {
 			return fmt.Errorf("failed to initialize TTS service: %w", err)
 		}
 		log.Println("TTS service initialized")
+		publishServiceStatus(ServiceTTS, true)
 	}
 
 	// Initialize embeddings service if enabled
@@ -144,14 +168,49 @@ func (m *Manager) Initialize(ctx context.Context) error {
 		embeddingConfig := &minilm.Config{
 			ModelPath: m.config.Models.MiniLM.Path,
 			Dimension: 384,
+			BatchSize: m.config.Models.MiniLM.BatchSize,
 		}
 
 		// Always use ONNX implementation with automatic model downloading
 		m.embeddingService = minilm.NewOnnxEmbeddingService(embeddingConfig)
 		if err := m.embeddingService.Initialize(ctx); err != nil {
-			return fmt.Errorf("failed to initialize embeddings service: %w", err)
+			// Keep the service registered so the model can be installed later
+			// through the download manager without restarting the backend
+			log.Printf("Warning: Failed to initialize embeddings service: %v", err)
+			log.Println("Embeddings will become available once the model is downloaded")
+		} else {
+			log.Println("Embeddings service initialized")
+		}
+		publishServiceStatus(ServiceEmbeddings, m.embeddingService.IsReady())
+
+		// Collections stay readable while the model is missing; only adding
+		// or querying by text needs the embeddings service
+		store, err := vectorstore.Open(m.config.VectorStore.Path)
+		if err != nil {
+			log.Printf("Warning: Failed to open vector store: %v", err)
+		} else {
+			m.vectorStore = store
 		}
-		log.Println("Embeddings service initialized")
+	}
+
+	// Initialize wake-word detection if enabled
+	if m.config.Features.WakeWord {
+		log.Println("Initializing wake-word service...")
+		wakeWordConfig := &wakeword.Config{
+			ModelPath:    m.config.Models.WakeWord.Path,
+			Model:        m.config.Models.WakeWord.Model,
+			KeywordModel: m.config.Models.WakeWord.KeywordModel,
+			Sensitivity:  m.config.Models.WakeWord.Sensitivity,
+		}
+
+		m.wakeWordService = wakeword.NewService(wakeWordConfig)
+		if err := m.wakeWordService.Initialize(ctx); err != nil {
+			// Like embeddings, the model can still be installed later
+			log.Printf("Warning: Failed to initialize wake-word service: %v", err)
+		} else {
+			log.Println("Wake-word service initialized")
+		}
+		publishServiceStatus(ServiceWakeWord, m.wakeWordService.IsReady())
 	}
 
 	log.Println("Model manager initialized successfully")
@@ -179,15 +238,42 @@ func (m *Manager) GetEmbeddingService() *minilm.OnnxEmbeddingService {
 	return m.embeddingService
 }
 
+// GetWakeWordService returns the wake-word service
+func (m *Manager) GetWakeWordService() *wakeword.Service {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.wakeWordService
+}
+
+// GetVectorStore returns the store of embedding collections
+func (m *Manager) GetVectorStore() *vectorstore.Store {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.vectorStore
+}
+
 // Shutdown gracefully shuts down all services
 func (m *Manager) Shutdown(ctx context.Context) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	log.Println("Shutting down model manager...")
 
 	var errs []error
 
+	// Stop running downloads before tearing down the services they install into
+	m.cancelJobs()
+	downloadsDone := make(chan struct{})
+	go func() {
+		m.jobsWG.Wait()
+		close(downloadsDone)
+	}()
+	select {
+	case <-downloadsDone:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("timed out waiting for downloads to stop: %w", ctx.Err()))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	// Close Whisper gRPC client if connected
 	if m.whisperGRPCClient != nil {
 		if err := m.whisperGRPCClient.Close(); err != nil {
@@ -206,18 +292,34 @@ func (m *Manager) Shutdown(ctx context.Context) error {
 		if err := m.sttService.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("STT shutdown error: %w", err))
 		}
+		publishServiceStatus(ServiceSTT, false)
 	}
 
 	if m.ttsService != nil {
 		if err := m.ttsService.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("TTS shutdown error: %w", err))
 		}
+		publishServiceStatus(ServiceTTS, false)
 	}
 
 	if m.embeddingService != nil {
 		if err := m.embeddingService.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("embeddings shutdown error: %w", err))
 		}
+		publishServiceStatus(ServiceEmbeddings, false)
+	}
+
+	if m.vectorStore != nil {
+		if err := m.vectorStore.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("vector store close error: %w", err))
+		}
+	}
+
+	if m.wakeWordService != nil {
+		if err := m.wakeWordService.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("wake-word shutdown error: %w", err))
+		}
+		publishServiceStatus(ServiceWakeWord, false)
 	}
 
 	if len(errs) > 0 {
@@ -237,6 +339,7 @@ func (m *Manager) GetStatus() map[string]interface{} {
 		"stt":        m.sttService != nil && m.sttService.IsReady(),
 		"tts":        m.ttsService != nil && m.ttsService.IsReady(),
 		"embeddings": m.embeddingService != nil && m.embeddingService.IsReady(),
+		"wakeword":   m.wakeWordService != nil && m.wakeWordService.IsReady(),
 	}
 
 	return status
@@ -0,0 +1,84 @@
+package piper
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidOptions is returned when synthesis options are out of range
+	ErrInvalidOptions = errors.New("invalid synthesis options")
+
+	// ErrInvalidSpeaker is returned when the requested speaker does not exist in the voice
+	ErrInvalidSpeaker = fmt.Errorf("%w: invalid speaker", ErrInvalidOptions)
+)
+
+// Accepted ranges of the per-request synthesis settings
+const (
+	minSpeed           = 0.25
+	maxSpeed           = 4.0
+	minLengthScale     = 0.1
+	maxLengthScale     = 5.0
+	maxNoiseScale      = 2.0
+	maxNoiseW          = 2.0
+	maxSentenceSilence = 10.0
+)
+
+// SynthesisOptions holds per-request synthesis settings. Unset values fall back
+// to the service configuration or the voice's own defaults.
+type SynthesisOptions struct {
+	// Speaker selects a speaker of a multi-speaker voice by name, SpeakerID by
+	// numeric id; at most one of them is set
+	Speaker   string
+	SpeakerID *int
+
+	// Speed is a playback speed multiplier; ignored when LengthScale is set
+	Speed float32
+
+	// LengthScale, NoiseScale and NoiseW map to the Piper CLI flags of the same name
+	LengthScale *float32
+	NoiseScale  *float32
+	NoiseW      *float32
+
+	// SentenceSilence is the pause inserted after each sentence, in seconds
+	SentenceSilence *float32
+}
+
+// Validate checks that all set options are within their accepted range
+func (o SynthesisOptions) Validate() error {
+	if o.Speaker != "" && o.SpeakerID != nil {
+		return fmt.Errorf("%w: specify either a speaker name or a speaker id, not both", ErrInvalidSpeaker)
+	}
+	if o.Speed != 0 && (o.Speed < minSpeed || o.Speed > maxSpeed) {
+		return fmt.Errorf("%w: speed must be between %.2f and %.1f", ErrInvalidOptions, minSpeed, maxSpeed)
+	}
+	if o.LengthScale != nil && (*o.LengthScale < minLengthScale || *o.LengthScale > maxLengthScale) {
+		return fmt.Errorf("%w: length_scale must be between %.1f and %.1f", ErrInvalidOptions, minLengthScale, maxLengthScale)
+	}
+	if o.NoiseScale != nil && (*o.NoiseScale < 0 || *o.NoiseScale > maxNoiseScale) {
+		return fmt.Errorf("%w: noise_scale must be between 0 and %.1f", ErrInvalidOptions, maxNoiseScale)
+	}
+	if o.NoiseW != nil && (*o.NoiseW < 0 || *o.NoiseW > maxNoiseW) {
+		return fmt.Errorf("%w: noise_w must be between 0 and %.1f", ErrInvalidOptions, maxNoiseW)
+	}
+	if o.SentenceSilence != nil && (*o.SentenceSilence < 0 || *o.SentenceSilence > maxSentenceSilence) {
+		return fmt.Errorf("%w: sentence_silence must be between 0 and %.0f seconds", ErrInvalidOptions, maxSentenceSilence)
+	}
+	return nil
+}
+
+// hasSpeaker reports whether a speaker is selected by name or id
+func (o SynthesisOptions) hasSpeaker() bool {
+	return o.Speaker != "" || o.SpeakerID != nil
+}
+
+// speed returns the effective speed multiplier of a request
+func (s *TTSService) speed(opts SynthesisOptions) float32 {
+	if opts.Speed > 0 {
+		return opts.Speed
+	}
+	if s.config.Speed > 0 {
+		return s.config.Speed
+	}
+	return 1.0
+}
@@ -0,0 +1,102 @@
+package piper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// AudioChunk is the audio of one sentence produced by SynthesizeStream
+type AudioChunk struct {
+	Index         int
+	Text          string
+	PCM           []byte // Little-endian PCM samples without a WAV header
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	Final         bool
+}
+
+// SynthesizeStream synthesizes text sentence by sentence and hands each
+// sentence's PCM to onChunk as soon as it is ready. Synthesis stops at the
+// first error returned by onChunk.
+func (s *TTSService) SynthesizeStream(ctx context.Context, text, voice string, opts SynthesisOptions, onChunk func(AudioChunk) error) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	sentences := splitSentences(text)
+	if len(sentences) == 0 {
+		return fmt.Errorf("text cannot be empty")
+	}
+
+	// The gRPC service splits and streams the sentences itself
+	s.mu.RLock()
+	if s.useGRPC && s.grpcClient != nil && s.grpcClient.IsConnected() {
+		client := s.grpcClient
+		s.mu.RUnlock()
+		log.Printf("[TTSService] Using Piper gRPC service for streaming synthesis")
+		return client.SynthesizeStream(ctx, text, voice, s.speed(opts), opts, onChunk)
+	}
+	s.mu.RUnlock()
+
+	log.Printf("[TTSService] Streaming synthesis of %d sentences", len(sentences))
+
+	for i, sentence := range sentences {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		audioData, err := s.SynthesizeWithOptions(ctx, sentence, voice, opts)
+		if err != nil {
+			return fmt.Errorf("failed to synthesize sentence %d: %w", i+1, err)
+		}
+
+		wavInfo, err := ParseWAVInfo(audioData)
+		if err != nil {
+			return fmt.Errorf("invalid audio for sentence %d: %w", i+1, err)
+		}
+
+		chunk := AudioChunk{
+			Index:         i,
+			Text:          sentence,
+			PCM:           audioData[wavInfo.DataOffset : wavInfo.DataOffset+wavInfo.DataSize],
+			SampleRate:    wavInfo.SampleRate,
+			Channels:      wavInfo.Channels,
+			BitsPerSample: wavInfo.BitsPerSample,
+			Final:         i == len(sentences)-1,
+		}
+		if err := onChunk(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitSentences splits text after sentence-ending punctuation followed by whitespace
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+
+	start := 0
+	for i, r := range runes {
+		if r != '.' && r != '!' && r != '?' && r != '\n' {
+			continue
+		}
+		if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && r != '\n' {
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start : i+1])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = i + 1
+	}
+
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
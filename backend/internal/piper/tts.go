@@ -5,25 +5,30 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/downloader"
 	"alice-backend/internal/embedded"
 )
 
 // PiperGRPCClient is an interface for the Piper gRPC client (for dependency injection)
 type PiperGRPCClient interface {
-	Synthesize(ctx context.Context, text, voice string, speed float32) ([]byte, error)
+	Synthesize(ctx context.Context, text, voice string, speed float32, opts SynthesisOptions) ([]byte, error)
+	SynthesizeStream(ctx context.Context, text, voice string, speed float32, opts SynthesisOptions, onChunk func(AudioChunk) error) error
 	IsConnected() bool
 	HealthCheck(ctx context.Context) (bool, error)
 }
@@ -39,6 +44,9 @@ type TTSService struct {
 	assetManager *embedded.AssetManager
 	grpcClient   PiperGRPCClient // gRPC client for service mode
 	useGRPC      bool            // Flag to enable gRPC mode
+	downloader   *downloader.Downloader
+	stopWatcher  context.CancelFunc
+	workers      *workerPool // Long-lived piper processes reused across requests
 }
 
 // Config holds TTS configuration
@@ -51,14 +59,18 @@ type Config struct {
 
 // Voice represents a TTS voice
 type Voice struct {
-	Name        string `json:"name"`
-	Language    string `json:"language"`
-	Gender      string `json:"gender"`
-	Quality     string `json:"quality"`
-	SampleRate  int    `json:"sample_rate"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Language    string   `json:"language"`
+	Gender      string   `json:"gender"`
+	Quality     string   `json:"quality"`
+	SampleRate  int      `json:"sample_rate"`
+	Description string   `json:"description"`
+	Speakers    []string `json:"speakers,omitempty"`
+
+	speakerIDs map[string]int
 }
 
+
 // ServiceInfo contains information about the TTS service
 type ServiceInfo struct {
 	Name        string            `json:"name"`
@@ -80,6 +92,8 @@ func NewTTSService(config *Config) *TTSService {
 		voices:       make(map[string]*Voice),
 		defaultVoice: "en_US-amy-medium",
 		assetManager: assetManager,
+		downloader:   downloader.NewDownloader(log.Default()),
+		workers:      newWorkerPool(),
 		info: &ServiceInfo{
 			Name:        "Piper TTS",
 			Version:     "1.0.0",
@@ -113,6 +127,11 @@ func (s *TTSService) Initialize(ctx context.Context) error {
 
 	s.loadVoices()
 
+	// Watch for voices added to or removed from the model directory at runtime
+	watchCtx, stopWatcher := context.WithCancel(context.Background())
+	s.stopWatcher = stopWatcher
+	go s.watchVoices(watchCtx)
+
 	s.ready = true
 	s.info.Status = "ready"
 	s.info.LastUpdated = time.Now()
@@ -122,214 +141,42 @@ func (s *TTSService) Initialize(ctx context.Context) error {
 }
 
 func (s *TTSService) loadVoices() {
-	// Define all available voices (metadata only)
-	allVoices := []*Voice{
-		{
-			Name:        "en_US-amy-medium",
-			Language:    "en-US",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Amy - English US female voice (Piper)",
-		},
-		{
-			Name:        "en_US-lessac-medium",
-			Language:    "en-US",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Lessac - English US female voice (Piper)",
-		},
-		{
-			Name:        "en_US-hfc_female-medium",
-			Language:    "en-US",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "HFC Female - English US female voice (Piper)",
-		},
-		{
-			Name:        "en_US-kristin-medium",
-			Language:    "en-US",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Kristin - English US female voice (Piper)",
-		},
-		{
-			Name:        "en_GB-alba-medium",
-			Language:    "en-GB",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Alba - English GB female voice (Piper)",
-		},
-		{
-			Name:        "es_ES-carme-medium",
-			Language:    "es-ES",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Carme - Spanish ES female voice (Piper)",
-		},
-		{
-			Name:        "es_MX-teresa-medium",
-			Language:    "es-MX",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Teresa - Spanish MX female voice (Piper)",
-		},
-		{
-			Name:        "es_MX-laura-high",
-			Language:    "es-MX",
-			Gender:      "female",
-			Quality:     "high",
-			SampleRate:  22050,
-			Description: "Laura - Spanish MX female voice (Piper)",
-		},
-		{
-			Name:        "fr_FR-siwis-medium",
-			Language:    "fr-FR",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Siwis - French female voice (Piper)",
-		},
-
-		{
-			Name:        "de_DE-eva_k-x_low",
-			Language:    "de-DE",
-			Gender:      "female",
-			Quality:     "x_low",
-			SampleRate:  16000,
-			Description: "Eva K - German female voice (Piper)",
-		},
-
-		{
-			Name:        "it_IT-paola-medium",
-			Language:    "it-IT",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Paola - Italian female voice (Piper)",
-		},
-
-		{
-			Name:        "pt_BR-lais-medium",
-			Language:    "pt-BR",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Lais - Portuguese BR female voice (Piper)",
-		},
-
-		{
-			Name:        "ru_RU-irina-medium",
-			Language:    "ru-RU",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Irina - Russian female voice (Piper)",
-		},
-		{
-			Name:        "zh_CN-huayan-medium",
-			Language:    "zh-CN",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Huayan - Chinese female voice (Piper)",
-		},
-		{
-			Name:        "ja_JP-qmu_amaryllis-medium",
-			Language:    "ja-JP",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Amaryllis - Japanese female voice (Piper)",
-		},
-
-		{
-			Name:        "nl_NL-mls_5809-low",
-			Language:    "nl-NL",
-			Gender:      "female",
-			Quality:     "low",
-			SampleRate:  16000,
-			Description: "MLS 5809 - Dutch female voice (Piper)",
-		},
-
-		{
-			Name:        "no_NO-talesyntese-medium",
-			Language:    "no-NO",
-			Gender:      "multi",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Talesyntese - Norwegian voice (Piper)",
-		},
-		{
-			Name:        "sv_SE-nst-medium",
-			Language:    "sv-SE",
-			Gender:      "multi",
-			Quality:     "medium", 
-			SampleRate:  22050,
-			Description: "NST - Swedish voice (Piper)",
-		},
-		{
-			Name:        "da_DK-talesyntese-medium",
-			Language:    "da-DK",
-			Gender:      "multi",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Talesyntese - Danish voice (Piper)",
-		},
-		{
-			Name:        "fi_FI-anna-medium",
-			Language:    "fi-FI", 
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Anna - Finnish female voice (Piper)",
-		},
-		{
-			Name:        "pl_PL-mls_6892-low",
-			Language:    "pl-PL",
-			Gender:      "female",
-			Quality:     "low",
-			SampleRate:  16000,
-			Description: "MLS 6892 - Polish female voice (Piper)",
-		},
-		{
-			Name:        "uk_UA-ukrainian_tts-medium",
-			Language:    "uk-UA",
-			Gender:      "multi",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Ukrainian TTS - Ukrainian voice (Piper)",
-		},
-		{
-			Name:        "hi_IN-female-medium",
-			Language:    "hi-IN",
-			Gender:      "female", 
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Female - Hindi voice (Piper)",
-		},
-		{
-			Name:        "ar_JO-amina-medium",
-			Language:    "ar-JO",
-			Gender:      "female",
-			Quality:     "medium",
-			SampleRate:  22050,
-			Description: "Amina - Arabic female voice (Piper)",
-		},
+	// All voices listed in the model catalog (metadata only)
+	catalogVoices := catalog.Default().Voices
+	allVoices := make([]*Voice, 0, len(catalogVoices))
+	for _, v := range catalogVoices {
+		allVoices = append(allVoices, &Voice{
+			Name:        v.Name,
+			Language:    v.Language,
+			Gender:      v.Gender,
+			Quality:     v.Quality,
+			SampleRate:  v.SampleRate,
+			Description: v.Description,
+		})
+	}
+
+	// Pick up metadata of installed voices, including ones the catalog doesn't list
+	modelDir := s.modelDir()
+	discovered := discoverVoices(modelDir)
+	for _, voice := range allVoices {
+		if cfg, ok := discovered[voice.Name]; ok {
+			cfg.apply(voice)
+			delete(discovered, voice.Name)
+		}
 	}
-
-	// Check which voices have models available (installed)
-	modelDir := "models/piper"
-	if s.config.ModelPath != "" {
-		modelDir = s.config.ModelPath
+	extraNames := make([]string, 0, len(discovered))
+	for name := range discovered {
+		extraNames = append(extraNames, name)
+	}
+	sort.Strings(extraNames)
+	for _, name := range extraNames {
+		voice := &Voice{Name: name}
+		discovered[name].apply(voice)
+		allVoices = append(allVoices, voice)
+		log.Printf("Discovered voice not in catalog: %s", name)
 	}
 
+	// Check which voices have models available (installed)
 	s.voices = make(map[string]*Voice)
 	installedVoices := []*Voice{}
 
@@ -403,7 +250,7 @@ func (s *TTSService) GetInfo() *ServiceInfo {
 }
 
 // synthesizeChunked splits long text into chunks and synthesizes each chunk separately
-func (s *TTSService) synthesizeChunked(ctx context.Context, text, voice string, maxChunkSize int) ([]byte, error) {
+func (s *TTSService) synthesizeChunked(ctx context.Context, text, voice string, opts SynthesisOptions, maxChunkSize int) ([]byte, error) {
 	chunks := splitTextIntoChunks(text, maxChunkSize)
 	log.Printf("[TTSService] Split text into %d chunks", len(chunks))
 
@@ -423,13 +270,9 @@ func (s *TTSService) synthesizeChunked(ctx context.Context, text, voice string,
 		s.mu.RUnlock()
 
 		if useGRPC {
-			speed := s.config.Speed
-			if speed == 0 {
-				speed = 1.0
-			}
-			chunkAudio, err = s.grpcClient.Synthesize(ctx, chunk, voice, speed)
+			chunkAudio, err = s.grpcClient.Synthesize(ctx, chunk, voice, s.speed(opts), opts)
 		} else {
-			chunkAudio, err = s.synthesizeWithPiper(ctx, chunk, voice)
+			chunkAudio, err = s.synthesizeWithPiper(ctx, chunk, voice, opts)
 		}
 
 		if err != nil {
@@ -505,10 +348,19 @@ func splitTextIntoChunks(text string, maxChunkSize int) []string {
 }
 
 func (s *TTSService) Synthesize(ctx context.Context, text string, voice string) ([]byte, error) {
+	return s.SynthesizeWithOptions(ctx, text, voice, SynthesisOptions{})
+}
+
+// SynthesizeWithOptions synthesizes text with per-request settings such as the speaker
+func (s *TTSService) SynthesizeWithOptions(ctx context.Context, text string, voice string, opts SynthesisOptions) ([]byte, error) {
 	if !s.IsReady() {
 		return nil, fmt.Errorf("TTS service is not ready")
 	}
 
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	if text == "" {
 		return nil, fmt.Errorf("text cannot be empty")
 	}
@@ -524,7 +376,7 @@ func (s *TTSService) Synthesize(ctx context.Context, text string, voice string)
 	const maxChunkSize = 500 // characters per chunk
 	if len(text) > maxChunkSize {
 		log.Printf("[TTSService] Text is long (%d chars), splitting into chunks", len(text))
-		return s.synthesizeChunked(ctx, text, voice, maxChunkSize)
+		return s.synthesizeChunked(ctx, text, voice, opts, maxChunkSize)
 	}
 
 	// Try gRPC mode if available
@@ -532,17 +384,19 @@ func (s *TTSService) Synthesize(ctx context.Context, text string, voice string)
 	if s.useGRPC && s.grpcClient != nil && s.grpcClient.IsConnected() {
 		s.mu.RUnlock()
 		log.Printf("[TTSService] Using Piper gRPC service for synthesis")
-		speed := s.config.Speed
-		if speed == 0 {
-			speed = 1.0
-		}
-		return s.grpcClient.Synthesize(ctx, text, voice, speed)
+		return s.grpcClient.Synthesize(ctx, text, voice, s.speed(opts), opts)
 	}
 
 	// Fallback to CLI mode
 	log.Printf("[TTSService] Using Piper CLI mode for synthesis")
 	selectedVoice, exists := s.voices[voice]
-	
+	if exists && opts.hasSpeaker() {
+		if _, err := selectedVoice.speakerID(opts); err != nil {
+			s.mu.RUnlock()
+			return nil, err
+		}
+	}
+
 	if !exists {
 		log.Printf("Voice '%s' not found, trying default voices...", voice)
 		if fallbackVoice, exists := s.voices[s.defaultVoice]; exists {
@@ -560,6 +414,11 @@ func (s *TTSService) Synthesize(ctx context.Context, text string, voice string)
 			}
 		}
 		exists = selectedVoice != nil
+		if opts.hasSpeaker() {
+			log.Printf("Ignoring speaker selection for fallback voice")
+			opts.Speaker = ""
+			opts.SpeakerID = nil
+		}
 	}
 	s.mu.RUnlock()
 
@@ -573,7 +432,7 @@ func (s *TTSService) Synthesize(ctx context.Context, text string, voice string)
 		return s.generatePlaceholderWAV(text, selectedVoice), nil
 	}
 
-	audioData, err := s.synthesizeWithPiper(ctx, text, voice)
+	audioData, err := s.synthesizeWithPiper(ctx, text, voice, opts)
 	if err != nil {
 		log.Printf("Failed to synthesize with Piper: %v", err)
 			return s.generatePlaceholderWAV(text, selectedVoice), nil
@@ -784,7 +643,7 @@ func (s *TTSService) ensurePiper(ctx context.Context) error {
 	}
 	log.Printf("Attempting to download Piper binary automatically...")
 	
-	if err := s.downloadPiperBinary(); err != nil {
+	if err := s.downloadPiperBinary(ctx); err != nil {
 		log.Printf("Failed to download Piper binary: %v", err)
 		log.Printf("Please download Piper manually from: https://github.com/rhasspy/piper/releases")
 		log.Printf("Extract the binary to: %s", s.config.PiperPath)
@@ -825,7 +684,7 @@ func (s *TTSService) ensureVoiceModel(ctx context.Context, voice string) error {
 
 	log.Printf("Voice model %s not found, attempting to download...", voice)
 	
-	if err := s.downloadVoiceModel(voice, modelDir); err != nil {
+	if err := s.downloadVoiceModel(ctx, voice, modelDir); err != nil {
 		log.Printf("Failed to download voice model: %v", err)
 		log.Printf("Please download manually from: https://huggingface.co/rhasspy/piper-voices/tree/main")
 		log.Printf("Place files at: %s and %s", modelFile, configFile)
@@ -836,37 +695,80 @@ func (s *TTSService) ensureVoiceModel(ctx context.Context, voice string) error {
 	return nil
 }
 
-func (s *TTSService) synthesizeWithPiper(ctx context.Context, text, voice string) ([]byte, error) {
-	modelDir := "models/piper"
-	if s.config.ModelPath != "" {
-		modelDir = s.config.ModelPath
-	}
+func (s *TTSService) synthesizeWithPiper(ctx context.Context, text, voice string, opts SynthesisOptions) ([]byte, error) {
+	modelFile := filepath.Join(s.modelDir(), voice+".onnx")
 
-	modelFile := filepath.Join(modelDir, voice+".onnx")
+	// Settings that piper only accepts on the command line; each combination
+	// gets its own pool of processes
+	args := []string{"--model", modelFile}
 
-	tmpDir := os.TempDir()
-	inputFile := filepath.Join(tmpDir, fmt.Sprintf("piper_input_%d.txt", time.Now().UnixNano()))
-	outputFile := filepath.Join(tmpDir, fmt.Sprintf("piper_output_%d.wav", time.Now().UnixNano()))
+	if opts.LengthScale != nil {
+		args = append(args, "--length_scale", fmt.Sprintf("%.2f", *opts.LengthScale))
+	} else if speed := s.speed(opts); speed != 1.0 {
+		args = append(args, "--length_scale", fmt.Sprintf("%.2f", 1.0/speed))
+	}
+	if opts.NoiseScale != nil {
+		args = append(args, "--noise_scale", fmt.Sprintf("%.3f", *opts.NoiseScale))
+	}
+	if opts.NoiseW != nil {
+		args = append(args, "--noise_w", fmt.Sprintf("%.3f", *opts.NoiseW))
+	}
+	if opts.SentenceSilence != nil {
+		args = append(args, "--sentence_silence", fmt.Sprintf("%.2f", *opts.SentenceSilence))
+	}
 
-	defer os.Remove(inputFile)
-	defer os.Remove(outputFile)
+	req := workerRequest{Text: text}
+	if opts.hasSpeaker() {
+		s.mu.RLock()
+		selectedVoice, exists := s.voices[voice]
+		s.mu.RUnlock()
+		if !exists {
+			return nil, fmt.Errorf("%w: unknown voice %s", ErrInvalidSpeaker, voice)
+		}
+		speakerID, err := selectedVoice.speakerID(opts)
+		if err != nil {
+			return nil, err
+		}
+		req.SpeakerID = &speakerID
+	}
 
-	if err := os.WriteFile(inputFile, []byte(text), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write input file: %w", err)
+	if !s.workers.supports(s.config.PiperPath) {
+		return s.synthesizeOnce(ctx, text, args, req.SpeakerID)
 	}
 
-	args := []string{
-		"--model", modelFile,
-		"--output-file", outputFile,
+	audioData, err := s.workers.synthesize(ctx, s.config.PiperPath, args, req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Older piper builds without --json-input still work one process per request
+		log.Printf("Piper worker failed, running piper directly: %v", err)
+		audioData, onceErr := s.synthesizeOnce(ctx, text, args, req.SpeakerID)
+		if onceErr == nil && errors.Is(err, errWorkerStartup) {
+			// Workers never got to a request but piper itself works, so
+			// --json-input is what it rejects
+			s.workers.markUnsupported(s.config.PiperPath)
+		}
+		return audioData, onceErr
 	}
 
-	if s.config.Speed > 0 && s.config.Speed != 1.0 {
-		args = append(args, "--length_scale", fmt.Sprintf("%.2f", 1.0/s.config.Speed))
+	log.Printf("Piper synthesis complete: %d bytes", len(audioData))
+	return audioData, nil
+}
+
+// synthesizeOnce runs a dedicated piper process for a single request
+func (s *TTSService) synthesizeOnce(ctx context.Context, text string, args []string, speakerID *int) ([]byte, error) {
+	outputFile := filepath.Join(os.TempDir(), fmt.Sprintf("piper_output_%d.wav", time.Now().UnixNano()))
+	defer os.Remove(outputFile)
+
+	args = append(append([]string{}, args...), "--output-file", outputFile)
+	if speakerID != nil {
+		args = append(args, "--speaker", strconv.Itoa(*speakerID))
 	}
 
 	cmd := exec.CommandContext(ctx, s.config.PiperPath, args...)
 	cmd.Stdin = strings.NewReader(text)
-	
+
 	espeakDataPath := filepath.Join(filepath.Dir(s.config.PiperPath), "espeak-ng-data")
 	cmd.Env = append(os.Environ(), "ESPEAK_DATA_PATH="+espeakDataPath)
 
@@ -884,65 +786,21 @@ func (s *TTSService) synthesizeWithPiper(ctx context.Context, text, voice string
 	return audioData, nil
 }
 
-func (s *TTSService) downloadPiperBinary() error {
-	var downloadURLs []string
-	var fileName string
-	
-	switch runtime.GOOS {
-	case "windows":
-		downloadURLs = []string{
-			"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_windows_amd64.zip",
-		}
-		fileName = "piper_windows_amd64.zip"
-	case "darwin":
-		if runtime.GOARCH == "arm64" {
-			downloadURLs = []string{
-				"https://raw.githubusercontent.com/pmbstyle/Alice/main/assets/binaries/piper-macos-arm64",
-				"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_macos_aarch64.tar.gz",
-			}
-			fileName = "piper-macos-arm64"
-		} else {
-			downloadURLs = []string{
-				"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_macos_x64.tar.gz",
-			}
-			fileName = "piper_macos_x64.tar.gz"
-		}
-	case "linux":
-		if runtime.GOARCH == "arm64" {
-			downloadURLs = []string{
-				"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_linux_aarch64.tar.gz",
-			}
-			fileName = "piper_linux_aarch64.tar.gz"
-		} else if runtime.GOARCH == "arm" {
-			downloadURLs = []string{
-				"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_linux_armv7l.tar.gz",
-			}
-			fileName = "piper_linux_armv7l.tar.gz"
-		} else {
-			downloadURLs = []string{
-				"https://github.com/rhasspy/piper/releases/download/2023.11.14-2/piper_linux_x86_64.tar.gz",
-			}
-			fileName = "piper_linux_x86_64.tar.gz"
-		}
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+func (s *TTSService) downloadPiperBinary(ctx context.Context) error {
+	binary, ok := catalog.Default().Binary("piper")
+	if !ok {
+		return fmt.Errorf("piper binary missing from model catalog")
+	}
+	artifact, ok := binary.ForPlatform(runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return fmt.Errorf("unsupported platform: %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
+	fileName := artifact.File
 
 	log.Printf("Downloading Piper binary for %s/%s", runtime.GOOS, runtime.GOARCH)
 	downloadPath := filepath.Join("bin", fileName)
-	var lastErr error
-	for i, downloadURL := range downloadURLs {
-		log.Printf("Attempting Piper download from source %d/%d: %s", i+1, len(downloadURLs), downloadURL)
-		if err := s.downloadFileWithRetry(downloadURL, downloadPath, 2); err != nil {
-			lastErr = err
-			log.Printf("Piper download source %d failed: %v", i+1, err)
-			continue
-		}
-		log.Printf("Piper download successful from source %d", i+1)
-		break
-	}
-	if _, err := os.Stat(downloadPath); err != nil {
-		return fmt.Errorf("failed to download Piper binary from any source: %w", lastErr)
+	if err := s.downloader.Fetch(ctx, artifact.Download("bin"), nil); err != nil {
+		return fmt.Errorf("failed to download Piper binary: %w", err)
 	}
 
 	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" && fileName == "piper-macos-arm64" {
@@ -965,90 +823,6 @@ func (s *TTSService) downloadPiperBinary() error {
 	return nil
 }
 
-func (s *TTSService) downloadFile(url, filepath string) error {
-	client := &http.Client{
-		Timeout: 5 * time.Minute,
-	}
-	
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("User-Agent", "AliceElectron/1.0 (compatible; file downloader)")
-	req.Header.Set("Accept", "application/octet-stream, */*")
-	req.Header.Set("Accept-Encoding", "identity")
-	req.Header.Set("Connection", "keep-alive")
-	
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-	case http.StatusNotFound:
-		return fmt.Errorf("file not found (status: 404) - Piper release may have been moved")
-	case http.StatusTooManyRequests:
-		return fmt.Errorf("rate limited (status: 429) - GitHub is limiting downloads")
-	case http.StatusServiceUnavailable:
-		return fmt.Errorf("service unavailable (status: 503) - GitHub servers temporarily down")
-	default:
-		if resp.StatusCode >= 400 {
-			return fmt.Errorf("download failed (status: %d) - %s", resp.StatusCode, resp.Status)
-		}
-	}
-
-	out, err := os.Create(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer out.Close()
-
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
-	}
-
-	log.Printf("Downloaded file: %s (%d bytes)", filepath, resp.ContentLength)
-	return nil
-}
-
-func (s *TTSService) downloadFileWithRetry(url, filepath string, maxRetries int) error {
-	var lastErr error
-	
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if attempt > 1 {
-			waitTime := time.Duration(1<<uint(attempt-2)) * 2 * time.Second
-			log.Printf("Retrying download in %v (attempt %d/%d)", waitTime, attempt, maxRetries)
-			time.Sleep(waitTime)
-		}
-		log.Printf("Download attempt %d/%d from: %s", attempt, maxRetries, url)
-		if err := s.downloadFile(url, filepath); err != nil {
-			lastErr = err
-			log.Printf("Attempt %d failed: %v", attempt, err)
-			
-			if _, statErr := os.Stat(filepath); statErr == nil {
-				os.Remove(filepath)
-			}
-			
-			continue
-		}
-		if info, err := os.Stat(filepath); err != nil {
-			lastErr = fmt.Errorf("downloaded file verification failed: %w", err)
-			continue
-		} else if info.Size() < 1000 {
-			lastErr = fmt.Errorf("downloaded file too small (%d bytes), likely an error page", info.Size())
-			os.Remove(filepath)
-			continue
-		}
-		log.Printf("Download successful on attempt %d", attempt)
-		return nil
-	}
-	
-	return fmt.Errorf("download failed after %d attempts: %w", maxRetries, lastErr)
-}
-
 func (s *TTSService) extractPiperBinary(archivePath string) error {
 	if strings.HasSuffix(archivePath, ".zip") {
 		return s.extractZip(archivePath)
@@ -1238,84 +1012,87 @@ func (s *TTSService) extractSingleFileFromTar(tarReader *tar.Reader, outputPath
 	return nil
 }
 
-func (s *TTSService) downloadVoiceModel(voiceName, modelDir string) error {
-	baseURL := "https://huggingface.co/rhasspy/piper-voices/resolve/main"
-	
-	voiceMapping := map[string]struct {
-		lang     string
-		voice    string
-		quality  string
-	}{
-		"en_US-amy-medium":        {"en/en_US", "amy", "medium"},
-		"en_US-lessac-medium":     {"en/en_US", "lessac", "medium"},
-		"en_US-hfc_female-medium": {"en/en_US", "hfc_female", "medium"},
-		"en_US-kristin-medium":    {"en/en_US", "kristin", "medium"},
-		"en_GB-alba-medium": {"en/en_GB", "alba", "medium"},
-
-		"es_ES-carme-medium":  {"es/es_ES", "carme", "medium"},
-		"es_MX-teresa-medium": {"es/es_MX", "teresa", "medium"},
-
-		"fr_FR-siwis-medium": {"fr/fr_FR", "siwis", "medium"},
-
-		"de_DE-eva_k-x_low": {"de/de_DE", "eva_k", "x_low"},
-
-		"it_IT-paola-medium": {"it/it_IT", "paola", "medium"},
-
-		"pt_BR-lais-medium": {"pt/pt_BR", "lais", "medium"},
-
-		"ru_RU-irina-medium": {"ru/ru_RU", "irina", "medium"},
-
-		"zh_CN-huayan-medium": {"zh/zh_CN", "huayan", "medium"},
+func (s *TTSService) downloadVoiceModel(ctx context.Context, voiceName, modelDir string) error {
+	files, err := voiceFiles(voiceName, modelDir)
+	if err != nil {
+		return err
+	}
 
-		"ja_JP-qmu_amaryllis-medium": {"ja/ja_JP", "qmu_amaryllis", "medium"},
+	for _, file := range files {
+		log.Printf("Downloading voice file: %s", file.URLs[0])
+		if err := s.downloader.Fetch(ctx, file, nil); err != nil {
+			return fmt.Errorf("failed to download %s: %w", filepath.Base(file.DestPath), err)
+		}
+	}
 
-		"nl_NL-mls_5809-low": {"nl/nl_NL", "mls_5809", "low"},
+	return nil
+}
 
-		"no_NO-talesyntese-medium": {"no/no_NO", "talesyntese", "medium"},
+// VoiceFiles returns the files that need to be downloaded to install a voice
+func (s *TTSService) VoiceFiles(voiceName string) ([]downloader.File, error) {
+	return voiceFiles(voiceName, s.modelDir())
+}
 
-		"sv_SE-nst-medium": {"sv/sv_SE", "nst", "medium"},
+// voiceFiles looks up the download locations of a voice in the model catalog
+func voiceFiles(voiceName, modelDir string) ([]downloader.File, error) {
+	voice, ok := catalog.Default().Voice(voiceName)
+	if !ok {
+		return nil, fmt.Errorf("unknown voice: %s", voiceName)
+	}
+	if len(voice.Files) == 0 {
+		return nil, fmt.Errorf("voice %s is not available for download", voiceName)
+	}
 
-		"da_DK-talesyntese-medium": {"da/da_DK", "talesyntese", "medium"},
+	files := make([]downloader.File, 0, len(voice.Files))
+	for _, artifact := range voice.Files {
+		files = append(files, artifact.Download(modelDir))
+	}
+	return files, nil
+}
 
-		"fi_FI-anna-medium": {"fi/fi_FI", "anna", "medium"},
+// IsVoiceInstalled reports whether the model and config of a voice are present on disk
+func (s *TTSService) IsVoiceInstalled(voiceName string) bool {
+	embeddedModel := s.assetManager.GetVoiceModelPath(voiceName)
+	if s.assetManager.IsAssetAvailable(embeddedModel) && s.assetManager.IsAssetAvailable(embeddedModel+".json") {
+		return true
+	}
 
-		"pl_PL-mls_6892-low": {"pl/pl_PL", "mls_6892", "low"},
+	modelFile := filepath.Join(s.modelDir(), voiceName+".onnx")
+	return fileExists(modelFile) && fileExists(modelFile+".json")
+}
 
-		"uk_UA-ukrainian_tts-medium": {"uk/uk_UA", "ukrainian_tts", "medium"},
+// ReloadVoices rescans the model directory so newly installed voices become usable
+func (s *TTSService) ReloadVoices() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		"hi_IN-female-medium": {"hi/hi_IN", "female", "medium"},
+	s.loadVoices()
+	s.workers.flush()
+	s.info.LastUpdated = time.Now()
+}
 
-		"ar_JO-amina-medium": {"ar/ar_JO", "amina", "medium"},
-	}
-	
-	voiceInfo, exists := voiceMapping[voiceName]
-	if !exists {
-		return fmt.Errorf("unknown voice: %s", voiceName)
-	}
-	
-	onnxURL := fmt.Sprintf("%s/%s/%s/%s/%s.onnx", baseURL, voiceInfo.lang, voiceInfo.voice, voiceInfo.quality, voiceName)
-	jsonURL := fmt.Sprintf("%s/%s/%s/%s/%s.onnx.json", baseURL, voiceInfo.lang, voiceInfo.voice, voiceInfo.quality, voiceName)
-	
-	onnxFile := filepath.Join(modelDir, voiceName+".onnx")
-	jsonFile := filepath.Join(modelDir, voiceName+".onnx.json")
-	
-	log.Printf("Downloading voice model: %s", onnxURL)
-	if err := s.downloadFileWithRetry(onnxURL, onnxFile, 3); err != nil {
-		return fmt.Errorf("failed to download .onnx file: %w", err)
-	}
-	
-	log.Printf("Downloading voice config: %s", jsonURL)
-	if err := s.downloadFileWithRetry(jsonURL, jsonFile, 3); err != nil {
-		return fmt.Errorf("failed to download .onnx.json file: %w", err)
+func (s *TTSService) modelDir() string {
+	if s.config.ModelPath != "" {
+		return s.config.ModelPath
 	}
-	
-	return nil
+	return "models/piper"
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 func (s *TTSService) Shutdown(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.stopWatcher != nil {
+		s.stopWatcher()
+		s.stopWatcher = nil
+	}
+	s.workers.close()
+
 	s.ready = false
 	s.info.Status = "stopped"
 	s.info.LastUpdated = time.Now()
@@ -0,0 +1,192 @@
+package piper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// voiceWatchInterval is how often the model directory is checked for added or removed voices
+const voiceWatchInterval = 5 * time.Second
+
+// voiceConfig is the subset of a Piper .onnx.json voice config used by the service
+type voiceConfig struct {
+	Audio struct {
+		SampleRate int    `json:"sample_rate"`
+		Quality    string `json:"quality"`
+	} `json:"audio"`
+	Language struct {
+		Code        string `json:"code"`
+		NameEnglish string `json:"name_english"`
+	} `json:"language"`
+	Dataset      string         `json:"dataset"`
+	NumSpeakers  int            `json:"num_speakers"`
+	SpeakerIDMap map[string]int `json:"speaker_id_map"`
+}
+
+// readVoiceConfig parses the .onnx.json config that accompanies a Piper voice model
+func readVoiceConfig(path string) (*voiceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg voiceConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid voice config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// speakers returns the speaker names of a multi-speaker voice ordered by speaker id
+func (c *voiceConfig) speakers() []string {
+	if len(c.SpeakerIDMap) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(c.SpeakerIDMap))
+	for name := range c.SpeakerIDMap {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return c.SpeakerIDMap[names[i]] < c.SpeakerIDMap[names[j]]
+	})
+	return names
+}
+
+// apply fills in voice metadata from the config, keeping curated values where present
+func (c *voiceConfig) apply(voice *Voice) {
+	if c.Audio.SampleRate > 0 {
+		voice.SampleRate = c.Audio.SampleRate
+	}
+	if voice.Quality == "" {
+		voice.Quality = c.Audio.Quality
+	}
+	if voice.Language == "" && c.Language.Code != "" {
+		voice.Language = strings.ReplaceAll(c.Language.Code, "_", "-")
+	}
+	if voice.Gender == "" {
+		voice.Gender = "unknown"
+		if c.NumSpeakers > 1 {
+			voice.Gender = "multi"
+		}
+	}
+	if voice.Description == "" {
+		name := c.Dataset
+		if name == "" {
+			name = voice.Name
+		}
+		if c.Language.NameEnglish != "" {
+			voice.Description = fmt.Sprintf("%s - %s voice (Piper)", name, c.Language.NameEnglish)
+		} else {
+			voice.Description = fmt.Sprintf("%s (Piper)", name)
+		}
+	}
+	voice.Speakers = c.speakers()
+	voice.speakerIDs = c.SpeakerIDMap
+}
+
+// speakerID resolves the speaker name or numeric id of opts to the id passed
+// to piper's --speaker flag. Names are only looked up in the speaker map.
+func (v *Voice) speakerID(opts SynthesisOptions) (int, error) {
+	if len(v.speakerIDs) == 0 {
+		return 0, fmt.Errorf("%w: voice %s has a single speaker", ErrInvalidSpeaker, v.Name)
+	}
+
+	if opts.SpeakerID != nil {
+		for _, known := range v.speakerIDs {
+			if known == *opts.SpeakerID {
+				return known, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: voice %s has no speaker id %d", ErrInvalidSpeaker, v.Name, *opts.SpeakerID)
+	}
+
+	if id, ok := v.speakerIDs[opts.Speaker]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("%w: voice %s has no speaker %q", ErrInvalidSpeaker, v.Name, opts.Speaker)
+}
+
+// discoverVoices scans a directory for Piper voices, i.e. <name>.onnx files with a
+// matching <name>.onnx.json config, and returns their parsed configs by voice name
+func discoverVoices(dir string) map[string]*voiceConfig {
+	configs := make(map[string]*voiceConfig)
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.onnx.json"))
+	if err != nil {
+		return configs
+	}
+
+	for _, configFile := range matches {
+		modelFile := strings.TrimSuffix(configFile, ".json")
+		if _, err := os.Stat(modelFile); err != nil {
+			continue
+		}
+
+		cfg, err := readVoiceConfig(configFile)
+		if err != nil {
+			log.Printf("Skipping voice %s: %v", filepath.Base(modelFile), err)
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(modelFile), ".onnx")
+		configs[name] = cfg
+	}
+
+	return configs
+}
+
+// voiceDirSignature summarizes the voice files in a directory so changes can be detected
+func voiceDirSignature(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".onnx") && !strings.HasSuffix(name, ".onnx.json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", name, info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
+
+// watchVoices polls the model directory and reloads the voice list when voices
+// are added, replaced or removed. It returns when ctx is cancelled.
+func (s *TTSService) watchVoices(ctx context.Context) {
+	ticker := time.NewTicker(voiceWatchInterval)
+	defer ticker.Stop()
+
+	dir := s.modelDir()
+	last := voiceDirSignature(dir)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := voiceDirSignature(dir)
+			if current == last {
+				continue
+			}
+			last = current
+
+			log.Printf("Voice directory %s changed, reloading voices", dir)
+			s.ReloadVoices()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package piper
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// WAVInfo describes the format of a PCM WAV clip
+type WAVInfo struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	DataOffset    int
+	DataSize      int
+}
+
+// Duration returns the length of the audio in seconds
+func (w *WAVInfo) Duration() float64 {
+	bytesPerSecond := w.SampleRate * w.Channels * w.BitsPerSample / 8
+	if bytesPerSecond == 0 {
+		return 0
+	}
+	return float64(w.DataSize) / float64(bytesPerSecond)
+}
+
+// ParseWAVInfo reads the format and data size from a RIFF/WAVE header
+func ParseWAVInfo(data []byte) (*WAVInfo, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+
+	info := &WAVInfo{}
+	haveFormat := false
+
+	// Walk the chunks following the RIFF header
+	offset := 12
+	for offset+8 <= len(data) {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 || body+16 > len(data) {
+				return nil, errors.New("truncated fmt chunk")
+			}
+			info.Channels = int(binary.LittleEndian.Uint16(data[body+2 : body+4]))
+			info.SampleRate = int(binary.LittleEndian.Uint32(data[body+4 : body+8]))
+			info.BitsPerSample = int(binary.LittleEndian.Uint16(data[body+14 : body+16]))
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("data chunk before fmt chunk")
+			}
+			// Streaming writers may leave the size unset; trust the buffer length instead
+			available := len(data) - body
+			if chunkSize == 0 || chunkSize > available {
+				chunkSize = available
+			}
+			info.DataOffset = body
+			info.DataSize = chunkSize
+			return info, nil
+		}
+
+		// Chunks are padded to an even size
+		offset = body + chunkSize + chunkSize%2
+	}
+
+	if !haveFormat {
+		return nil, errors.New("missing fmt chunk")
+	}
+	return nil, errors.New("missing data chunk")
+}
@@ -0,0 +1,360 @@
+package piper
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxWorkersPerKey limits concurrent piper processes per voice and settings
+	maxWorkersPerKey = 2
+
+	// workerIdleTimeout is how long an unused piper process is kept alive
+	workerIdleTimeout = 5 * time.Minute
+
+	// workerSweepInterval is how often idle processes are checked for eviction
+	workerSweepInterval = 30 * time.Second
+)
+
+var (
+	// errWorkerExited is returned when a piper process dies while handling a request
+	errWorkerExited = errors.New("piper process exited")
+
+	// errWorkerStartup is returned when a piper process dies before handling
+	// its first request, as builds without --json-input do
+	errWorkerStartup = fmt.Errorf("%w before handling a request", errWorkerExited)
+)
+
+// workerRequest is one line of piper's --json-input protocol
+type workerRequest struct {
+	Text      string `json:"text"`
+	SpeakerID *int   `json:"speaker_id,omitempty"`
+}
+
+// piperWorker is a long-lived piper process with its voice model loaded.
+//
+// Piper's --output_raw mode writes bare samples to stdout without marking where
+// an utterance ends, so workers run with --output_dir instead: piper writes one
+// WAV per input line and prints its path once the file is complete.
+type piperWorker struct {
+	key       string
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	lines     chan string
+	exited    chan struct{}
+	outputDir string
+	lastUsed  time.Time
+	served    bool
+	stopped   bool
+}
+
+// startWorker launches a piper process that reads requests from stdin
+func startWorker(key, binary string, args []string) (*piperWorker, error) {
+	outputDir, err := os.MkdirTemp("", "piper-worker-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker output directory: %w", err)
+	}
+
+	cmdArgs := append(append([]string{}, args...), "--json-input", "--output_dir", outputDir)
+	cmd := exec.Command(binary, cmdArgs...)
+
+	espeakDataPath := filepath.Join(filepath.Dir(binary), "espeak-ng-data")
+	cmd.Env = append(os.Environ(), "ESPEAK_DATA_PATH="+espeakDataPath)
+	cmd.Stderr = io.Discard
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		os.RemoveAll(outputDir)
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(outputDir)
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(outputDir)
+		return nil, fmt.Errorf("failed to start piper: %w", err)
+	}
+
+	w := &piperWorker{
+		key:       key,
+		cmd:       cmd,
+		stdin:     stdin,
+		lines:     make(chan string, 1),
+		exited:    make(chan struct{}),
+		outputDir: outputDir,
+		lastUsed:  time.Now(),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			w.lines <- strings.TrimSpace(scanner.Text())
+		}
+		err := cmd.Wait()
+		log.Printf("[PiperWorker] Process for %s exited: %v", key, err)
+		close(w.exited)
+	}()
+
+	log.Printf("[PiperWorker] Started piper process (pid %d) for %s", cmd.Process.Pid, key)
+	return w, nil
+}
+
+// synthesize sends one request to the process and returns the resulting WAV
+func (w *piperWorker) synthesize(ctx context.Context, req workerRequest) ([]byte, error) {
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	exited := errWorkerExited
+	if !w.served {
+		exited = errWorkerStartup
+	}
+
+	if _, err := w.stdin.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("%w: %v", exited, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		// The process is still busy with this request; it can't be reused
+		w.stop()
+		return nil, ctx.Err()
+	case <-w.exited:
+		return nil, exited
+	case path := <-w.lines:
+		w.served = true
+		defer os.Remove(path)
+		audioData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read piper output: %w", err)
+		}
+		w.lastUsed = time.Now()
+		return audioData, nil
+	}
+}
+
+// alive reports whether the process is still running and usable
+func (w *piperWorker) alive() bool {
+	if w.stopped {
+		return false
+	}
+	select {
+	case <-w.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// stop terminates the process and removes its output directory
+func (w *piperWorker) stop() {
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	w.stdin.Close()
+	w.cmd.Process.Kill()
+	go func() {
+		<-w.exited
+		os.RemoveAll(w.outputDir)
+	}()
+}
+
+// workerPool keeps piper processes alive between requests, keyed by voice model
+// and the command-line settings the process was started with
+type workerPool struct {
+	mu      sync.Mutex
+	idle    map[string][]*piperWorker
+	running map[string]int
+	legacy  map[string]bool // Piper binaries found to lack --json-input
+	freed   chan struct{}
+	done    chan struct{}
+	closed  bool
+}
+
+func newWorkerPool() *workerPool {
+	p := &workerPool{
+		idle:    make(map[string][]*piperWorker),
+		running: make(map[string]int),
+		legacy:  make(map[string]bool),
+		freed:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go p.evictIdle()
+	return p
+}
+
+// synthesize runs a request on a pooled process, restarting it once if it crashed
+func (p *workerPool) synthesize(ctx context.Context, binary string, args []string, req workerRequest) ([]byte, error) {
+	key := strings.Join(args, " ")
+
+	for attempt := 1; ; attempt++ {
+		w, err := p.acquire(ctx, key, binary, args)
+		if err != nil {
+			return nil, err
+		}
+
+		audioData, err := w.synthesize(ctx, req)
+		p.release(w)
+
+		if errors.Is(err, errWorkerExited) && attempt < 2 {
+			log.Printf("[PiperWorker] Process for %s crashed, restarting", key)
+			continue
+		}
+		return audioData, err
+	}
+}
+
+// supports reports whether binary can run pooled processes; it is false once
+// the binary was found to lack --json-input
+func (p *workerPool) supports(binary string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.legacy[binary]
+}
+
+// markUnsupported records that binary has no --json-input, so requests go
+// straight to a process per request instead of failing to start workers first
+func (p *workerPool) markUnsupported(binary string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.legacy[binary] {
+		log.Printf("[PiperWorker] %s does not support --json-input, running one process per request", binary)
+		p.legacy[binary] = true
+	}
+}
+
+// acquire returns an idle process for key, starting a new one if the limit allows
+func (p *workerPool) acquire(ctx context.Context, key, binary string, args []string) (*piperWorker, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, errors.New("worker pool is closed")
+		}
+
+		for len(p.idle[key]) > 0 {
+			workers := p.idle[key]
+			w := workers[len(workers)-1]
+			p.idle[key] = workers[:len(workers)-1]
+			if w.alive() {
+				p.mu.Unlock()
+				return w, nil
+			}
+			p.running[key]--
+		}
+
+		if p.running[key] < maxWorkersPerKey {
+			p.running[key]++
+			p.mu.Unlock()
+
+			w, err := startWorker(key, binary, args)
+			if err != nil {
+				p.mu.Lock()
+				p.running[key]--
+				p.mu.Unlock()
+				return nil, err
+			}
+			return w, nil
+		}
+		p.mu.Unlock()
+
+		// All processes for this key are busy; wait for one to be released
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-p.freed:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// release returns a process to the pool, discarding it if it died
+func (p *workerPool) release(w *piperWorker) {
+	p.mu.Lock()
+	if w.alive() && !p.closed {
+		p.idle[w.key] = append(p.idle[w.key], w)
+	} else {
+		p.running[w.key]--
+		w.stop()
+	}
+	p.mu.Unlock()
+
+	select {
+	case p.freed <- struct{}{}:
+	default:
+	}
+}
+
+// evictIdle stops processes that have not been used for workerIdleTimeout
+func (p *workerPool) evictIdle() {
+	ticker := time.NewTicker(workerSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		for key, workers := range p.idle {
+			kept := workers[:0]
+			for _, w := range workers {
+				if time.Since(w.lastUsed) > workerIdleTimeout || !w.alive() {
+					log.Printf("[PiperWorker] Stopping idle process for %s", key)
+					p.running[key]--
+					w.stop()
+					continue
+				}
+				kept = append(kept, w)
+			}
+			p.idle[key] = kept
+		}
+		p.mu.Unlock()
+	}
+}
+
+// flush stops all idle processes so the next request loads models from disk again
+func (p *workerPool) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, workers := range p.idle {
+		for _, w := range workers {
+			p.running[key]--
+			w.stop()
+		}
+		delete(p.idle, key)
+	}
+}
+
+// close stops all idle processes; busy ones are stopped when released
+func (p *workerPool) close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	p.flush()
+}
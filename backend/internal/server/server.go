@@ -34,41 +34,8 @@ func (s *Server) Start(port string) error {
 	router.Use(loggingMiddleware)
 	router.Use(recoveryMiddleware)
 
-	// API routes
-	apiRouter := router.PathPrefix("/api").Subrouter()
-
-	// Health check
-	apiRouter.HandleFunc("/health", s.handler.HealthCheck).Methods("GET")
-	apiRouter.HandleFunc("/config", s.handler.GetConfig).Methods("GET")
-
-	// STT routes
-	sttRouter := apiRouter.PathPrefix("/stt").Subrouter()
-	sttRouter.HandleFunc("/transcribe", s.handler.TranscribeAudio).Methods("POST")
-	sttRouter.HandleFunc("/transcribe-audio", s.handler.TranscribeAudio).Methods("POST")
-	sttRouter.HandleFunc("/transcribe-file", s.handler.TranscribeAudio).Methods("POST")
-	sttRouter.HandleFunc("/ready", s.handler.STTReady).Methods("GET")
-	sttRouter.HandleFunc("/info", s.handler.STTInfo).Methods("GET")
-
-	// TTS routes
-	ttsRouter := apiRouter.PathPrefix("/tts").Subrouter()
-	ttsRouter.HandleFunc("/synthesize", s.handler.SynthesizeSpeech).Methods("POST")
-	ttsRouter.HandleFunc("/voices", s.handler.GetVoices).Methods("GET")
-	ttsRouter.HandleFunc("/ready", s.handler.TTSReady).Methods("GET")
-	ttsRouter.HandleFunc("/info", s.handler.TTSInfo).Methods("GET")
-
-	// Embeddings routes
-	embeddingsRouter := apiRouter.PathPrefix("/embeddings").Subrouter()
-	embeddingsRouter.HandleFunc("/generate", s.handler.GenerateEmbedding).Methods("POST")
-	embeddingsRouter.HandleFunc("/batch", s.handler.GenerateEmbeddings).Methods("POST")
-	embeddingsRouter.HandleFunc("/generate-batch", s.handler.GenerateEmbeddings).Methods("POST")
-	embeddingsRouter.HandleFunc("/ready", s.handler.EmbeddingsReady).Methods("GET")
-	embeddingsRouter.HandleFunc("/info", s.handler.EmbeddingsInfo).Methods("GET")
-
-	// Model management routes
-	modelsRouter := apiRouter.PathPrefix("/models").Subrouter()
-	modelsRouter.HandleFunc("/download/{service}", s.handler.DownloadModel).Methods("POST")
-	modelsRouter.HandleFunc("/status", s.handler.GetModelStatus).Methods("GET")
-	modelsRouter.HandleFunc("/download-status", s.handler.GetModelDownloadStatus).Methods("GET")
+	// API routes are declared by the API modules
+	s.handler.RegisterRoutes(router)
 
 	handler := corsMiddleware(router)
 
@@ -94,7 +61,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 					w.Header().Set("Access-Control-Allow-Origin", origin)
 					w.Header().Set("Vary", "Origin")
 					w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-					w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+					w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Sample-Rate, X-Encoding")
 					w.Header().Set("Access-Control-Allow-Credentials", "true")
 				}
 			}
@@ -0,0 +1,403 @@
+package vectorstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// compactThreshold is the number of log entries after which a collection
+	// writes a snapshot and empties its log
+	compactThreshold = 1000
+
+	// exactSearchLimit is the collection size below which queries scan every
+	// record instead of the index; a scan is exact and about as fast there
+	exactSearchLimit = 1024
+
+	// rebuildRatio is the share of deleted nodes at which the index is
+	// rebuilt when the collection is compacted
+	rebuildRatio = 0.25
+)
+
+// Record is an entry of a collection. Vectors are stored normalized, so the
+// dot product of two vectors is their cosine similarity.
+type Record struct {
+	ID       string          `json:"id"`
+	Text     string          `json:"text,omitempty"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Vector   []float32       `json:"vector"`
+}
+
+// Match is a record returned by a query with its cosine similarity to the query
+type Match struct {
+	ID       string          `json:"id"`
+	Text     string          `json:"text,omitempty"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Score    float32         `json:"score"`
+}
+
+// QueryOptions tunes a query
+type QueryOptions struct {
+	TopK     int  // Number of matches; defaults to 5
+	EfSearch int  // Overrides the collection's index setting
+	Exact    bool // Scan every record instead of using the index
+}
+
+// CollectionInfo describes a collection
+type CollectionInfo struct {
+	Name      string      `json:"name"`
+	Dimension int         `json:"dimension"`
+	Count     int         `json:"count"`
+	Index     IndexConfig `json:"index"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Collection is a named set of records persisted in its own directory as a
+// snapshot plus a write-ahead log of later changes
+type Collection struct {
+	mu        sync.RWMutex
+	name      string
+	dir       string
+	dimension int // Set by the first record unless given at creation
+	createdAt time.Time
+	updatedAt time.Time
+	records   map[string]*Record
+	index     *hnsw
+	wal       *wal
+}
+
+// openCollection loads a collection from its snapshot and log
+func openCollection(dir string) (*Collection, error) {
+	c := &Collection{
+		name:    filepath.Base(dir),
+		dir:     dir,
+		records: make(map[string]*Record),
+	}
+
+	snap, err := readSnapshot(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		return nil, err
+	}
+	if snap != nil {
+		c.dimension = snap.Dimension
+		c.createdAt = snap.CreatedAt
+		c.updatedAt = snap.UpdatedAt
+		for i := range snap.Records {
+			c.records[snap.Records[i].ID] = &snap.Records[i]
+		}
+	}
+
+	var config IndexConfig
+	if snap != nil && snap.Index != nil {
+		config = snap.Index.Config
+		if index, ok := restoreHNSW(snap.Index, c.records); ok {
+			c.index = index
+		} else {
+			log.Printf("[VectorStore] Index of collection %s does not match its records, rebuilding", c.name)
+		}
+	}
+	if c.index == nil {
+		c.rebuildIndex(config)
+	}
+
+	if c.wal, err = openWAL(filepath.Join(dir, walFile)); err != nil {
+		return nil, err
+	}
+	if err := c.wal.replay(c.apply); err != nil {
+		c.wal.close()
+		return nil, fmt.Errorf("failed to replay log of collection %s: %w", c.name, err)
+	}
+	if c.createdAt.IsZero() {
+		c.createdAt = time.Now()
+	}
+	return c, nil
+}
+
+// Name returns the name of the collection
+func (c *Collection) Name() string {
+	return c.name
+}
+
+// Info returns a description of the collection
+func (c *Collection) Info() CollectionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return CollectionInfo{
+		Name:      c.name,
+		Dimension: c.dimension,
+		Count:     len(c.records),
+		Index:     c.index.config,
+		CreatedAt: c.createdAt,
+		UpdatedAt: c.updatedAt,
+	}
+}
+
+// Upsert adds records or replaces those with the same ID, and returns their
+// IDs. Records without an ID get a random one.
+func (c *Collection) Upsert(records []Record) ([]string, error) {
+	if len(records) == 0 {
+		return []string{}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dimension := c.dimension
+	entry := walEntry{Op: opUpsert, Time: time.Now()}
+	ids := make([]string, len(records))
+	for i, record := range records {
+		if dimension == 0 {
+			dimension = len(record.Vector)
+		}
+		if len(record.Vector) != dimension || dimension == 0 {
+			return nil, fmt.Errorf("%w: record %d has %d dimensions, collection has %d", ErrDimensionMismatch, i, len(record.Vector), dimension)
+		}
+
+		vector, err := normalize(record.Vector)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		record.Vector = vector
+		if record.ID == "" {
+			record.ID = newID()
+		}
+		ids[i] = record.ID
+		entry.Records = append(entry.Records, record)
+	}
+
+	if err := c.commit(entry); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Delete removes records by ID and returns how many existed
+func (c *Collection) Delete(ids []string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := c.records[id]; ok {
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	if err := c.commit(walEntry{Op: opDelete, IDs: ids, Time: time.Now()}); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// Get returns a record by ID
+func (c *Collection) Get(id string) (Record, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	record, ok := c.records[id]
+	if !ok {
+		return Record{}, false
+	}
+	return *record, true
+}
+
+// Query returns the records most similar to vector, best first. Large
+// collections are searched through the index, so results are approximate.
+func (c *Collection) Query(vector []float32, opts QueryOptions) ([]Match, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.records) == 0 {
+		return []Match{}, nil
+	}
+	if len(vector) != c.dimension {
+		return nil, fmt.Errorf("%w: query has %d dimensions, collection has %d", ErrDimensionMismatch, len(vector), c.dimension)
+	}
+	query, err := normalize(vector)
+	if err != nil {
+		return nil, err
+	}
+	topK := opts.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+
+	if !opts.Exact && len(c.records) >= exactSearchLimit {
+		found := c.index.Search(query, topK, opts.EfSearch)
+		matches := make([]Match, len(found))
+		for i, f := range found {
+			record := c.records[c.index.nodes[f.node].id]
+			matches[i] = Match{
+				ID:       record.ID,
+				Text:     record.Text,
+				Metadata: record.Metadata,
+				Score:    1 - f.dist,
+			}
+		}
+		return matches, nil
+	}
+
+	matches := make([]Match, 0, len(c.records))
+	for _, record := range c.records {
+		matches = append(matches, Match{
+			ID:       record.ID,
+			Text:     record.Text,
+			Metadata: record.Metadata,
+			Score:    dot(query, record.Vector),
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	if topK < len(matches) {
+		matches = matches[:topK]
+	}
+	return matches, nil
+}
+
+// commit logs an entry, applies it and compacts the log when it has grown;
+// called with c.mu held
+func (c *Collection) commit(entry walEntry) error {
+	if err := c.wal.append(entry); err != nil {
+		return fmt.Errorf("failed to write log of collection %s: %w", c.name, err)
+	}
+	if err := c.apply(entry); err != nil {
+		return err
+	}
+
+	if c.wal.entries >= compactThreshold {
+		if err := c.compact(); err != nil {
+			// The change is stored in the log, so it succeeded; the next
+			// commit tries to compact again
+			log.Printf("[VectorStore] Failed to compact collection %s: %v", c.name, err)
+		}
+	}
+	return nil
+}
+
+// apply changes the in-memory state; used for new and replayed entries
+func (c *Collection) apply(entry walEntry) error {
+	switch entry.Op {
+	case opUpsert:
+		for i := range entry.Records {
+			record := entry.Records[i]
+			if c.dimension == 0 {
+				c.dimension = len(record.Vector)
+			}
+			c.records[record.ID] = &record
+			c.index.Insert(record.ID, record.Vector)
+		}
+	case opDelete:
+		for _, id := range entry.IDs {
+			delete(c.records, id)
+			c.index.Delete(id)
+		}
+	default:
+		return fmt.Errorf("unknown log operation %q", entry.Op)
+	}
+	c.updatedAt = entry.Time
+	return nil
+}
+
+// compact writes a snapshot and empties the log; called with c.mu held
+func (c *Collection) compact() error {
+	if c.index.tombstoneRatio() >= rebuildRatio {
+		c.rebuildIndex(c.index.config)
+	}
+
+	snap := &snapshot{
+		Version:   snapshotVersion,
+		Name:      c.name,
+		Dimension: c.dimension,
+		CreatedAt: c.createdAt,
+		UpdatedAt: c.updatedAt,
+		Records:   make([]Record, 0, len(c.records)),
+	}
+	for _, record := range c.records {
+		snap.Records = append(snap.Records, *record)
+	}
+	snap.Index = c.index.snapshot()
+
+	if err := writeSnapshot(filepath.Join(c.dir, snapshotFile), snap); err != nil {
+		return err
+	}
+	return c.wal.reset()
+}
+
+// rebuildIndex indexes every record in a new graph, dropping tombstones
+func (c *Collection) rebuildIndex(config IndexConfig) {
+	ids := make([]string, 0, len(c.records))
+	for id := range c.records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	c.index = newHNSW(config)
+	for _, id := range ids {
+		c.index.Insert(id, c.records[id].Vector)
+	}
+}
+
+// close snapshots the collection and closes its log
+func (c *Collection) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	if c.wal.entries > 0 {
+		err = c.compact()
+	}
+	if closeErr := c.wal.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// normalize returns a unit-length copy of v
+func normalize(v []float32) ([]float32, error) {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 || math.IsNaN(norm) || math.IsInf(norm, 0) {
+		return nil, fmt.Errorf("vector must have a finite, non-zero length")
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = x * scale
+	}
+	return out, nil
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func newID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,386 @@
+package vectorstore
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Index defaults, following the HNSW paper's recommendations for
+// medium-dimensional embeddings
+const (
+	DefaultM              = 16
+	DefaultEfConstruction = 200
+	DefaultEfSearch       = 64
+)
+
+// IndexConfig tunes the HNSW graph of a collection. M is the number of
+// neighbours per node (twice that on the bottom layer), EfConstruction the
+// candidate list size while inserting and EfSearch while querying. Larger
+// values trade speed and memory for recall.
+type IndexConfig struct {
+	M              int `json:"m"`
+	EfConstruction int `json:"ef_construction"`
+	EfSearch       int `json:"ef_search"`
+}
+
+// withDefaults fills unset parameters
+func (c IndexConfig) withDefaults() IndexConfig {
+	if c.M <= 1 {
+		c.M = DefaultM
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = DefaultEfConstruction
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = DefaultEfSearch
+	}
+	return c
+}
+
+// hnsw is a Hierarchical Navigable Small World graph (Malkov & Yashunin) over
+// normalized vectors, using 1 - dot product as the distance. Deleted nodes
+// stay in the graph as tombstones so it remains navigable; they are skipped
+// in results and dropped when the graph is rebuilt.
+type hnsw struct {
+	config    IndexConfig
+	levelMult float64
+	nodes     []*hnswNode
+	ids       map[string]int32 // Live record ID -> node
+	entry     int32            // -1 while the graph is empty
+	maxLevel  int
+	deleted   int
+	rng       *rand.Rand
+}
+
+type hnswNode struct {
+	id        string
+	vector    []float32
+	neighbors [][]int32 // Per layer, from 0 up to the node's level
+	deleted   bool
+}
+
+func newHNSW(config IndexConfig) *hnsw {
+	config = config.withDefaults()
+	return &hnsw{
+		config:    config,
+		levelMult: 1 / math.Log(float64(config.M)),
+		ids:       make(map[string]int32),
+		entry:     -1,
+		rng:       rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// Len returns the number of live nodes
+func (h *hnsw) Len() int {
+	return len(h.ids)
+}
+
+// tombstoneRatio is the share of deleted nodes in the graph
+func (h *hnsw) tombstoneRatio() float64 {
+	if len(h.nodes) == 0 {
+		return 0
+	}
+	return float64(h.deleted) / float64(len(h.nodes))
+}
+
+// Insert adds a vector, replacing an existing node with the same ID
+func (h *hnsw) Insert(id string, vector []float32) {
+	h.Delete(id)
+
+	level := int(-math.Log(1-h.rng.Float64()) * h.levelMult)
+	node := &hnswNode{id: id, vector: vector, neighbors: make([][]int32, level+1)}
+	n := int32(len(h.nodes))
+	h.nodes = append(h.nodes, node)
+	h.ids[id] = n
+
+	if h.entry < 0 {
+		h.entry = n
+		h.maxLevel = level
+		return
+	}
+
+	// Descend greedily through the layers above the new node's level
+	ep := h.entry
+	epDist := h.distance(vector, ep)
+	for l := h.maxLevel; l > level; l-- {
+		ep, epDist = h.greedy(vector, ep, epDist, l)
+	}
+
+	entryPoints := []candidate{{ep, epDist}}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		found := h.searchLayer(vector, entryPoints, h.config.EfConstruction, l)
+		node.neighbors[l] = h.selectNeighbors(found, h.maxNeighbors(l))
+
+		for _, neighbor := range node.neighbors[l] {
+			h.link(neighbor, n, l)
+		}
+		entryPoints = found
+	}
+
+	if level > h.maxLevel {
+		h.entry = n
+		h.maxLevel = level
+	}
+}
+
+// Delete marks the node of an ID as deleted
+func (h *hnsw) Delete(id string) {
+	n, ok := h.ids[id]
+	if !ok {
+		return
+	}
+	delete(h.ids, id)
+	h.nodes[n].deleted = true
+	h.deleted++
+}
+
+// Search returns the k live nodes closest to query, nearest first
+func (h *hnsw) Search(query []float32, k, ef int) []candidate {
+	if h.entry < 0 || k <= 0 {
+		return nil
+	}
+	if ef <= 0 {
+		ef = h.config.EfSearch
+	}
+	// Tombstones take up room in the candidate list
+	ef = max(ef, k) + min(h.deleted, k)
+
+	ep := h.entry
+	epDist := h.distance(query, ep)
+	for l := h.maxLevel; l > 0; l-- {
+		ep, epDist = h.greedy(query, ep, epDist, l)
+	}
+
+	found := h.searchLayer(query, []candidate{{ep, epDist}}, ef, 0)
+	results := make([]candidate, 0, k)
+	for _, c := range found {
+		if !h.nodes[c.node].deleted {
+			results = append(results, c)
+			if len(results) == k {
+				break
+			}
+		}
+	}
+	return results
+}
+
+// candidate is a node with its distance to the vector being searched for
+type candidate struct {
+	node int32
+	dist float32
+}
+
+func (h *hnsw) distance(v []float32, n int32) float32 {
+	return 1 - dot(v, h.nodes[n].vector)
+}
+
+func (h *hnsw) maxNeighbors(level int) int {
+	if level == 0 {
+		return 2 * h.config.M
+	}
+	return h.config.M
+}
+
+// greedy walks to the closest node on a layer, starting at ep
+func (h *hnsw) greedy(v []float32, ep int32, epDist float32, level int) (int32, float32) {
+	for changed := true; changed; {
+		changed = false
+		for _, neighbor := range h.nodes[ep].neighbors[level] {
+			if d := h.distance(v, neighbor); d < epDist {
+				ep, epDist, changed = neighbor, d, true
+			}
+		}
+	}
+	return ep, epDist
+}
+
+// searchLayer returns up to ef nodes of a layer closest to v, nearest first
+func (h *hnsw) searchLayer(v []float32, entryPoints []candidate, ef int, level int) []candidate {
+	visited := make(map[int32]bool, ef*4)
+	candidates := &minHeap{}
+	results := &maxHeap{}
+	for _, ep := range entryPoints {
+		visited[ep.node] = true
+		heap.Push(candidates, ep)
+		heap.Push(results, ep)
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		closest := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && closest.dist > (*results)[0].dist {
+			break
+		}
+
+		for _, neighbor := range h.nodes[closest.node].neighbors[level] {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+
+			d := h.distance(v, neighbor)
+			if results.Len() < ef || d < (*results)[0].dist {
+				heap.Push(candidates, candidate{neighbor, d})
+				heap.Push(results, candidate{neighbor, d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	found := make([]candidate, results.Len())
+	for i := len(found) - 1; i >= 0; i-- {
+		found[i] = heap.Pop(results).(candidate)
+	}
+	return found
+}
+
+// selectNeighbors picks up to m of the candidates (sorted nearest first) with
+// the paper's heuristic, which prefers neighbours in different directions so
+// clusters stay connected. Free slots are filled with the nearest rejects.
+func (h *hnsw) selectNeighbors(candidates []candidate, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var rejected []int32
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+		diverse := true
+		for _, s := range selected {
+			if dot(h.nodes[c.node].vector, h.nodes[s].vector) > 1-c.dist {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, c.node)
+		} else {
+			rejected = append(rejected, c.node)
+		}
+	}
+
+	for _, r := range rejected {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, r)
+	}
+	return selected
+}
+
+// link adds a connection from node to neighbor on a layer, pruning the
+// node's connections if it has too many
+func (h *hnsw) link(node, neighbor int32, level int) {
+	n := h.nodes[node]
+	n.neighbors[level] = append(n.neighbors[level], neighbor)
+	if len(n.neighbors[level]) <= h.maxNeighbors(level) {
+		return
+	}
+
+	candidates := make([]candidate, len(n.neighbors[level]))
+	for i, c := range n.neighbors[level] {
+		candidates[i] = candidate{c, h.distance(n.vector, c)}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+	n.neighbors[level] = h.selectNeighbors(candidates, h.maxNeighbors(level))
+}
+
+// indexSnapshot is the persisted form of the graph. Vectors of live nodes
+// come from the records; tombstones keep their own for navigation.
+type indexSnapshot struct {
+	Config   IndexConfig
+	Entry    int32
+	MaxLevel int
+	Nodes    []nodeSnapshot
+}
+
+type nodeSnapshot struct {
+	ID        string
+	Neighbors [][]int32
+	Deleted   bool
+	Vector    []float32 // Only set for tombstones
+}
+
+func (h *hnsw) snapshot() *indexSnapshot {
+	snap := &indexSnapshot{
+		Config:   h.config,
+		Entry:    h.entry,
+		MaxLevel: h.maxLevel,
+		Nodes:    make([]nodeSnapshot, len(h.nodes)),
+	}
+	for i, node := range h.nodes {
+		snap.Nodes[i] = nodeSnapshot{ID: node.id, Neighbors: node.neighbors, Deleted: node.deleted}
+		if node.deleted {
+			snap.Nodes[i].Vector = node.vector
+		}
+	}
+	return snap
+}
+
+// restoreHNSW rebuilds the graph from a snapshot; ok is false if the
+// snapshot doesn't match the records
+func restoreHNSW(snap *indexSnapshot, records map[string]*Record) (*hnsw, bool) {
+	h := newHNSW(snap.Config)
+	h.entry = snap.Entry
+	h.maxLevel = snap.MaxLevel
+	h.nodes = make([]*hnswNode, len(snap.Nodes))
+	for i, ns := range snap.Nodes {
+		node := &hnswNode{id: ns.ID, neighbors: ns.Neighbors, deleted: ns.Deleted, vector: ns.Vector}
+		if !ns.Deleted {
+			record, ok := records[ns.ID]
+			if !ok {
+				return nil, false
+			}
+			node.vector = record.Vector
+			h.ids[ns.ID] = int32(i)
+		} else {
+			h.deleted++
+		}
+		for _, layer := range ns.Neighbors {
+			for _, neighbor := range layer {
+				if neighbor < 0 || int(neighbor) >= len(snap.Nodes) {
+					return nil, false
+				}
+			}
+		}
+		h.nodes[i] = node
+	}
+
+	if len(h.ids) != len(records) || (h.entry < 0) != (len(h.nodes) == 0) || int(h.entry) >= len(h.nodes) {
+		return nil, false
+	}
+	return h, true
+}
+
+// minHeap orders candidates nearest first
+type minHeap []candidate
+
+func (q minHeap) Len() int            { return len(q) }
+func (q minHeap) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q minHeap) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *minHeap) Push(x interface{}) { *q = append(*q, x.(candidate)) }
+func (q *minHeap) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// maxHeap orders candidates farthest first
+type maxHeap []candidate
+
+func (q maxHeap) Len() int            { return len(q) }
+func (q maxHeap) Less(i, j int) bool  { return q[i].dist > q[j].dist }
+func (q maxHeap) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *maxHeap) Push(x interface{}) { *q = append(*q, x.(candidate)) }
+func (q *maxHeap) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,188 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"alice-backend/internal/minilm"
+)
+
+const (
+	testDimension = 32
+	testRecords   = 2000
+	testQueries   = 50
+	testTopK      = 10
+	minRecall     = 0.95
+)
+
+// randomVectors returns n normalized vectors drawn around a few centroids, so
+// the data has some of the structure of real embeddings
+func randomVectors(rng *rand.Rand, n int) [][]float32 {
+	centroids := make([][]float32, 20)
+	for i := range centroids {
+		centroids[i] = make([]float32, testDimension)
+		for j := range centroids[i] {
+			centroids[i][j] = float32(rng.NormFloat64())
+		}
+	}
+
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		centroid := centroids[rng.Intn(len(centroids))]
+		vectors[i] = make([]float32, testDimension)
+		for j := range vectors[i] {
+			vectors[i][j] = centroid[j] + float32(rng.NormFloat64())
+		}
+		vectors[i], _ = normalize(vectors[i])
+	}
+	return vectors
+}
+
+// bruteForce returns the IDs of the topK candidates that SearchSimilar ranks highest
+func bruteForce(t *testing.T, query []float32, ids []string, candidates [][]float32) map[string]bool {
+	t.Helper()
+
+	service := minilm.NewOnnxEmbeddingService(&minilm.Config{})
+	indices, _, err := service.SearchSimilar(context.Background(), query, candidates, testTopK)
+	if err != nil {
+		t.Fatalf("SearchSimilar failed: %v", err)
+	}
+
+	expected := make(map[string]bool, len(indices))
+	for _, i := range indices {
+		expected[ids[i]] = true
+	}
+	return expected
+}
+
+// recall is the share of the expected IDs among those found
+func recall(expected map[string]bool, found []string) float64 {
+	hits := 0
+	for _, id := range found {
+		if expected[id] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(expected))
+}
+
+func TestHNSWRecall(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	vectors := randomVectors(rng, testRecords)
+	queries := randomVectors(rng, testQueries)
+
+	index := newHNSW(IndexConfig{})
+	ids := make([]string, len(vectors))
+	for i, vector := range vectors {
+		ids[i] = fmt.Sprintf("r%d", i)
+		index.Insert(ids[i], vector)
+	}
+
+	assertRecall(t, index, queries, ids, vectors)
+
+	// Delete a third of the records, leaving tombstones in the graph
+	var liveIDs []string
+	var liveVectors [][]float32
+	for i := range vectors {
+		if i%3 == 0 {
+			index.Delete(ids[i])
+			continue
+		}
+		liveIDs = append(liveIDs, ids[i])
+		liveVectors = append(liveVectors, vectors[i])
+	}
+	if index.Len() != len(liveIDs) {
+		t.Fatalf("index has %d live nodes, want %d", index.Len(), len(liveIDs))
+	}
+	assertRecall(t, index, queries, liveIDs, liveVectors)
+}
+
+func assertRecall(t *testing.T, index *hnsw, queries [][]float32, ids []string, vectors [][]float32) {
+	t.Helper()
+
+	total := 0.0
+	for _, query := range queries {
+		found := index.Search(query, testTopK, 0)
+		names := make([]string, len(found))
+		for i, c := range found {
+			if index.nodes[c.node].deleted {
+				t.Fatalf("search returned deleted record %s", index.nodes[c.node].id)
+			}
+			names[i] = index.nodes[c.node].id
+		}
+		total += recall(bruteForce(t, query, ids, vectors), names)
+	}
+
+	if r := total / float64(len(queries)); r < minRecall {
+		t.Errorf("recall@%d is %.3f, want at least %.2f", testTopK, r, minRecall)
+	}
+}
+
+func TestCollectionIndexPersists(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(2))
+	vectors := randomVectors(rng, testRecords)
+	queries := randomVectors(rng, testQueries)
+
+	store, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := IndexConfig{M: 12, EfConstruction: 100, EfSearch: 80}
+	collection, err := store.Create("memories", testDimension, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := make([]Record, len(vectors))
+	ids := make([]string, len(vectors))
+	for i, vector := range vectors {
+		ids[i] = fmt.Sprintf("r%d", i)
+		records[i] = Record{ID: ids[i], Vector: vector}
+	}
+	if _, err := collection.Upsert(records); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := collection.Delete(ids[:100]); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	collection, err = store.Collection("memories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info := collection.Info(); info.Index != config || info.Count != len(ids)-100 {
+		t.Fatalf("reopened collection has index %+v and %d records, want %+v and %d", info.Index, info.Count, config, len(ids)-100)
+	}
+	if collection.index.deleted != 100 {
+		t.Errorf("reopened index has %d tombstones, want 100; it was rebuilt instead of loaded", collection.index.deleted)
+	}
+
+	total := 0.0
+	for _, query := range queries {
+		matches, err := collection.Query(query, QueryOptions{TopK: testTopK})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := make([]string, len(matches))
+		for i, match := range matches {
+			found[i] = match.ID
+		}
+		total += recall(bruteForce(t, query, ids[100:], vectors[100:]), found)
+	}
+
+	if r := total / float64(len(queries)); r < minRecall {
+		t.Errorf("recall@%d is %.3f, want at least %.2f", testTopK, r, minRecall)
+	}
+}
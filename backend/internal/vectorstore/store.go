@@ -0,0 +1,169 @@
+// Package vectorstore keeps named collections of embeddings on disk so they
+// can be searched without sending every candidate with each query.
+package vectorstore
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCollectionNotFound is returned for a collection that does not exist
+	ErrCollectionNotFound = errors.New("collection not found")
+
+	// ErrCollectionExists is returned when creating a collection that already exists
+	ErrCollectionExists = errors.New("collection already exists")
+
+	// ErrInvalidName is returned for a collection name that can't be used as a directory
+	ErrInvalidName = errors.New("collection names may only contain letters, digits, '-' and '_' (at most 64)")
+
+	// ErrDimensionMismatch is returned for vectors whose length differs from the collection's
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+)
+
+var collectionName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Store manages the collections in a directory, one subdirectory each
+type Store struct {
+	mu          sync.RWMutex
+	dir         string
+	collections map[string]*Collection
+}
+
+// Open loads every collection in dir, creating the directory if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{dir: dir, collections: make(map[string]*Collection)}
+	for _, entry := range entries {
+		if !entry.IsDir() || !collectionName.MatchString(entry.Name()) {
+			continue
+		}
+		c, err := openCollection(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// Leave a damaged collection on disk for inspection
+			log.Printf("[VectorStore] Skipping collection %s: %v", entry.Name(), err)
+			continue
+		}
+		s.collections[c.name] = c
+	}
+
+	log.Printf("[VectorStore] Opened %d collections in %s", len(s.collections), dir)
+	return s, nil
+}
+
+// Create creates an empty collection. A dimension of zero is taken from the
+// first record; unset index parameters use the defaults.
+func (s *Store) Create(name string, dimension int, index IndexConfig) (*Collection, error) {
+	if !collectionName.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+	if dimension < 0 {
+		return nil, fmt.Errorf("dimension must not be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.collections[name]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrCollectionExists, name)
+	}
+
+	dir := filepath.Join(s.dir, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	// Persist the dimension and creation time before any record is added
+	now := time.Now()
+	if err := writeSnapshot(filepath.Join(dir, snapshotFile), &snapshot{
+		Version:   snapshotVersion,
+		Name:      name,
+		Dimension: dimension,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Index:     newHNSW(index).snapshot(),
+	}); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	c, err := openCollection(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	s.collections[name] = c
+	return c, nil
+}
+
+// Collection returns a collection by name
+func (s *Store) Collection(name string) (*Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCollectionNotFound, name)
+	}
+	return c, nil
+}
+
+// List describes every collection, sorted by name
+func (s *Store) List() []CollectionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	infos := make([]CollectionInfo, 0, len(s.collections))
+	for _, c := range s.collections {
+		infos = append(infos, c.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Drop deletes a collection and its files
+func (s *Store) Drop(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCollectionNotFound, name)
+	}
+	delete(s.collections, name)
+
+	c.mu.Lock()
+	c.wal.close()
+	c.mu.Unlock()
+	return os.RemoveAll(c.dir)
+}
+
+// Close snapshots every collection so the next start doesn't replay their logs
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for name, c := range s.collections {
+		if err := c.close(); err != nil {
+			errs = append(errs, fmt.Errorf("collection %s: %w", name, err))
+		}
+	}
+	s.collections = make(map[string]*Collection)
+	return errors.Join(errs...)
+}
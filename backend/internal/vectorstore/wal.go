@@ -0,0 +1,204 @@
+package vectorstore
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files of a collection directory
+const (
+	snapshotFile = "snapshot.gob"
+	walFile      = "wal.log"
+)
+
+// snapshotVersion is the snapshot format written by this backend; version 1
+// snapshots have no index and are re-indexed when loaded
+const snapshotVersion = 2
+
+// maxEntrySize caps the payload of a log entry, so a corrupt length field
+// can't make replay allocate an arbitrary amount of memory
+const maxEntrySize = 256 << 20
+
+// Log operations
+const (
+	opUpsert = "upsert"
+	opDelete = "delete"
+)
+
+// walEntry is one logged change of a collection
+type walEntry struct {
+	Op      string    `json:"op"`
+	Records []Record  `json:"records,omitempty"`
+	IDs     []string  `json:"ids,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// snapshot is the full state of a collection at the time it was written
+type snapshot struct {
+	Version   int
+	Name      string
+	Dimension int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Records   []Record
+	Index     *indexSnapshot
+}
+
+// wal is an append-only log of the changes made since the last snapshot.
+// Each entry is framed by its length and CRC-32, so an entry torn by a crash
+// is detected and dropped on replay.
+type wal struct {
+	file    *os.File
+	entries int
+}
+
+func openWAL(path string) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &wal{file: file}, nil
+}
+
+// replay applies every intact entry in the log. A damaged tail is truncated
+// so new entries are appended after the last good one.
+func (w *wal) replay(apply func(walEntry) error) error {
+	info, err := w.file.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	var offset int64
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(w.file, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return w.truncate(offset, err)
+		}
+
+		length := int64(binary.LittleEndian.Uint32(header[:4]))
+		if length > maxEntrySize || length > info.Size()-offset-int64(len(header)) {
+			return w.truncate(offset, fmt.Errorf("invalid entry length %d", length))
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(w.file, payload); err != nil {
+			return w.truncate(offset, err)
+		}
+		if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:]) {
+			return w.truncate(offset, errors.New("checksum mismatch"))
+		}
+
+		var entry walEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			return w.truncate(offset, err)
+		}
+		if err := apply(entry); err != nil {
+			return err
+		}
+
+		offset += int64(len(header) + len(payload))
+		w.entries++
+	}
+}
+
+// truncate drops a damaged log tail starting at offset
+func (w *wal) truncate(offset int64, cause error) error {
+	log.Printf("[VectorStore] Dropping damaged log tail of %s at offset %d: %v", w.file.Name(), offset, cause)
+	return w.file.Truncate(offset)
+}
+
+// append writes an entry and syncs it to disk
+func (w *wal) append(entry walEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxEntrySize {
+		return fmt.Errorf("log entry of %d bytes exceeds the limit of %d bytes", len(payload), maxEntrySize)
+	}
+
+	frame := make([]byte, 8, 8+len(payload))
+	binary.LittleEndian.PutUint32(frame[:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:], crc32.ChecksumIEEE(payload))
+	frame = append(frame, payload...)
+
+	if _, err := w.file.Write(frame); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.entries++
+	return nil
+}
+
+// reset empties the log once its entries are part of a snapshot
+func (w *wal) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	w.entries = 0
+	return w.file.Sync()
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
+
+// readSnapshot loads a snapshot; a missing file yields nil
+func readSnapshot(path string) (*snapshot, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snap snapshot
+	if err := gob.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+	if snap.Version < 1 || snap.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s", snap.Version, path)
+	}
+	return &snap, nil
+}
+
+// writeSnapshot atomically replaces the snapshot at path
+func writeSnapshot(path string, snap *snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), snapshotFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,202 @@
+package vectorstore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestCollection creates a collection in a fresh store directory
+func newTestCollection(t *testing.T) *Collection {
+	t.Helper()
+
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection, err := store.Create("notes", testDimension, IndexConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return collection
+}
+
+// crash closes the log of a collection without the snapshot a clean close
+// writes, and loads the collection again from disk
+func crash(t *testing.T, c *Collection) *Collection {
+	t.Helper()
+
+	if err := c.wal.close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := openCollection(c.dir)
+	if err != nil {
+		t.Fatalf("failed to reopen collection: %v", err)
+	}
+	return reopened
+}
+
+// upsertRecords adds n records named prefix0, prefix1, ... with random vectors
+func upsertRecords(t *testing.T, c *Collection, rng *rand.Rand, prefix string, n int) []Record {
+	t.Helper()
+
+	vectors := randomVectors(rng, n)
+	records := make([]Record, n)
+	for i := range records {
+		records[i] = Record{ID: fmt.Sprintf("%s%d", prefix, i), Text: prefix, Vector: vectors[i]}
+	}
+	if _, err := c.Upsert(records); err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+// assertRecords checks that a collection holds exactly the given records and
+// that its index finds each of them
+func assertRecords(t *testing.T, c *Collection, want []Record) {
+	t.Helper()
+
+	if info := c.Info(); info.Count != len(want) {
+		t.Fatalf("collection has %d records, want %d", info.Count, len(want))
+	}
+	if c.index.Len() != len(want) {
+		t.Fatalf("index has %d live nodes, want %d", c.index.Len(), len(want))
+	}
+	for _, record := range want {
+		got, ok := c.Get(record.ID)
+		if !ok {
+			t.Fatalf("record %s is missing", record.ID)
+		}
+		if got.Text != record.Text {
+			t.Errorf("record %s has text %q, want %q", record.ID, got.Text, record.Text)
+		}
+		if dot(got.Vector, record.Vector) < 0.999 {
+			t.Errorf("record %s has a different vector", record.ID)
+		}
+
+		found := c.index.Search(record.Vector, 1, 0)
+		if len(found) == 0 || c.index.nodes[found[0].node].id != record.ID {
+			t.Errorf("index does not find record %s", record.ID)
+		}
+	}
+}
+
+func TestWALReplay(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	c := newTestCollection(t)
+
+	first := upsertRecords(t, c, rng, "a", 20)
+	second := upsertRecords(t, c, rng, "b", 20)
+
+	c = crash(t, c)
+	if c.wal.entries != 2 {
+		t.Errorf("replayed %d log entries, want 2", c.wal.entries)
+	}
+	assertRecords(t, c, append(first, second...))
+}
+
+func TestWALDeleteReplay(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	c := newTestCollection(t)
+
+	records := upsertRecords(t, c, rng, "a", 20)
+	if _, err := c.Delete([]string{"a0", "a1", "a2", "a3", "a4"}); err != nil {
+		t.Fatal(err)
+	}
+	// Replace a record after deleting it, so the order of entries matters
+	readded := upsertRecords(t, c, rng, "a", 1)
+
+	c = crash(t, c)
+	for _, id := range []string{"a1", "a2", "a3", "a4"} {
+		if _, ok := c.Get(id); ok {
+			t.Errorf("deleted record %s was restored", id)
+		}
+	}
+	assertRecords(t, c, append(readded, records[5:]...))
+}
+
+func TestWALTornTail(t *testing.T) {
+	header := func(length, crc uint32) []byte {
+		frame := make([]byte, 8)
+		binary.LittleEndian.PutUint32(frame[:4], length)
+		binary.LittleEndian.PutUint32(frame[4:], crc)
+		return frame
+	}
+
+	tests := []struct {
+		name string
+		tail []byte
+	}{
+		{"partial header", []byte{0x10, 0x00, 0x00}},
+		{"partial payload", append(header(100, 0), `{"op":"upsert"`...)},
+		{"checksum mismatch", append(header(16, 0xdeadbeef), `{"op":"delete"}`...)},
+		{"length beyond file", header(1<<20, 0)},
+		{"length above limit", header(maxEntrySize+1, 0)},
+		{"garbage", []byte("not a log entry at all")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(5))
+			c := newTestCollection(t)
+			records := upsertRecords(t, c, rng, "a", 10)
+
+			path := filepath.Join(c.dir, walFile)
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.wal.file.Write(tt.tail); err != nil {
+				t.Fatal(err)
+			}
+
+			c = crash(t, c)
+			assertRecords(t, c, records)
+
+			// The damaged tail is cut off, so new entries follow the last good one
+			if info2, err := os.Stat(path); err != nil || info2.Size() != info.Size() {
+				t.Fatalf("log was not truncated to %d bytes: %v, %v", info.Size(), info2.Size(), err)
+			}
+			more := upsertRecords(t, c, rng, "b", 5)
+			c = crash(t, c)
+			assertRecords(t, c, append(records, more...))
+		})
+	}
+}
+
+func TestSnapshotAndWALRecovery(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	c := newTestCollection(t)
+
+	snapshotted := upsertRecords(t, c, rng, "a", 30)
+	c.mu.Lock()
+	err := c.compact()
+	c.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.wal.entries != 0 {
+		t.Fatalf("log has %d entries after compaction, want 0", c.wal.entries)
+	}
+
+	// Later changes only live in the log: new records, and deletes and
+	// replacements of records in the snapshot
+	logged := upsertRecords(t, c, rng, "b", 10)
+	if _, err := c.Delete([]string{"a0", "a1", "a2", "b0"}); err != nil {
+		t.Fatal(err)
+	}
+	replaced := Record{ID: "a3", Text: "replaced", Vector: randomVectors(rng, 1)[0]}
+	if _, err := c.Upsert([]Record{replaced}); err != nil {
+		t.Fatal(err)
+	}
+
+	c = crash(t, c)
+	if c.wal.entries != 3 {
+		t.Errorf("replayed %d log entries, want 3", c.wal.entries)
+	}
+	want := append([]Record{replaced}, snapshotted[4:]...)
+	assertRecords(t, c, append(want, logged[1:]...))
+}
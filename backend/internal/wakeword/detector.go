@@ -0,0 +1,172 @@
+package wakeword
+
+import (
+	"errors"
+	"time"
+
+	"alice-backend/internal/events"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+const (
+	// chunkSamples is the step of the feature models (80ms at 16kHz)
+	chunkSamples = 1280
+
+	// melContext is the extra audio fed to the mel model so consecutive
+	// chunks produce contiguous frames (three 10ms hops)
+	melContext = 480
+
+	// melBins is the number of mel bands per spectrogram frame
+	melBins = 32
+
+	// cooldownSamples suppresses repeated detections of one utterance (2s)
+	cooldownSamples = 2 * 16000
+)
+
+// Detector scores a continuous 16kHz mono stream for the wake word. A
+// Detector is not safe for concurrent use.
+type Detector struct {
+	service   *Service
+	threshold float64
+	onDetect  func(Detection)
+
+	pending  []float32   // Samples not yet filling a whole chunk
+	audio    []float32   // Recent audio scaled to the int16 range
+	mel      [][]float32 // Recent mel spectrogram frames
+	features [][]float32 // Recent speech embeddings
+
+	processed     int64 // Samples consumed since the stream started
+	cooldownUntil int64
+}
+
+// NewDetector creates a detector for one audio stream. sensitivity ranges
+// from 0 to 1; zero uses the service default. onDetect is called from Write.
+func (s *Service) NewDetector(sensitivity float64, onDetect func(Detection)) (*Detector, error) {
+	if !s.IsReady() {
+		return nil, errors.New("wake-word service is not ready")
+	}
+	if sensitivity == 0 {
+		sensitivity = s.Sensitivity()
+	}
+	if sensitivity <= 0 || sensitivity >= 1 {
+		return nil, errors.New("sensitivity must be between 0 and 1 (exclusive)")
+	}
+
+	return &Detector{
+		service:   s,
+		threshold: 1 - sensitivity,
+		onDetect:  onDetect,
+	}, nil
+}
+
+// Write feeds 16kHz mono samples into the detector
+func (d *Detector) Write(samples []float32) error {
+	d.pending = append(d.pending, samples...)
+	for len(d.pending) >= chunkSamples {
+		// Consume the chunk even if scoring it fails, as it may already be in
+		// the audio context; feeding it again would repeat it
+		chunk := d.pending[:chunkSamples]
+		d.pending = d.pending[chunkSamples:]
+		d.processed += chunkSamples
+
+		detection, err := d.processChunk(chunk)
+		if err != nil {
+			return err
+		}
+
+		if detection != nil {
+			d.service.detections.Publish(events.Event{Type: TypeDetected, Service: "wakeword", Data: *detection})
+			if d.onDetect != nil {
+				d.onDetect(*detection)
+			}
+		}
+	}
+	return nil
+}
+
+// processChunk advances the feature pipeline by one chunk and scores it,
+// returning a detection if the wake word was heard
+func (d *Detector) processChunk(chunk []float32) (*Detection, error) {
+	d.service.mu.RLock()
+	defer d.service.mu.RUnlock()
+
+	p := d.service.pipeline
+	if p == nil {
+		return nil, errors.New("wake-word service is not ready")
+	}
+
+	// The feature models expect samples in the int16 range
+	for _, v := range chunk {
+		d.audio = append(d.audio, v*32767)
+	}
+	if excess := len(d.audio) - (chunkSamples + melContext); excess > 0 {
+		d.audio = append(d.audio[:0], d.audio[excess:]...)
+	}
+
+	spec, err := p.melspec.run(ort.NewShape(1, int64(len(d.audio))), d.audio)
+	if err != nil {
+		return nil, err
+	}
+	if d.mel == nil {
+		// Start from the same neutral spectrogram openWakeWord uses
+		for i := 0; i < p.melWindow; i++ {
+			d.mel = append(d.mel, onesFrame())
+		}
+	}
+	for i := 0; i+melBins <= len(spec); i += melBins {
+		frame := make([]float32, melBins)
+		for j := range frame {
+			frame[j] = spec[i+j]/10 + 2
+		}
+		d.mel = append(d.mel, frame)
+	}
+	d.mel = d.mel[max(0, len(d.mel)-p.melWindow):]
+
+	window := make([]float32, 0, p.melWindow*melBins)
+	for _, frame := range d.mel {
+		window = append(window, frame...)
+	}
+	embedding, err := p.embedding.run(ort.NewShape(1, int64(p.melWindow), melBins, 1), window)
+	if err != nil {
+		return nil, err
+	}
+	d.features = append(d.features, embedding)
+	if len(d.features) < p.featureWindow {
+		return nil, nil // Still filling the first classifier window
+	}
+	d.features = d.features[len(d.features)-p.featureWindow:]
+
+	var input []float32
+	for _, feature := range d.features {
+		input = append(input, feature...)
+	}
+	scores, err := p.classifier.run(ort.NewShape(1, int64(p.featureWindow), int64(len(embedding))), input)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return nil, errors.New("keyword model returned no score")
+	}
+
+	score := float64(scores[0])
+	if score < d.threshold || d.processed < d.cooldownUntil {
+		return nil, nil
+	}
+	d.cooldownUntil = d.processed + cooldownSamples
+
+	return &Detection{
+		Keyword:    p.keyword,
+		Score:      score,
+		Time:       float64(d.processed) / 16000,
+		DetectedAt: time.Now(),
+	}, nil
+}
+
+func onesFrame() []float32 {
+	frame := make([]float32, melBins)
+	for i := range frame {
+		frame[i] = 1
+	}
+	return frame
+}
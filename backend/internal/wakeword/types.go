@@ -0,0 +1,43 @@
+package wakeword
+
+import (
+	"time"
+)
+
+// Config holds wake-word configuration
+type Config struct {
+	// ModelPath is the directory the feature and keyword models are stored in
+	ModelPath string
+
+	// Model is the catalog model to use; empty selects the catalog default
+	Model string
+
+	// KeywordModel optionally points to a custom keyword classifier trained
+	// for the openWakeWord feature models, e.g. hey_alice.onnx. The catalog
+	// has no "hey alice" model, so this is the only way to wake on it.
+	KeywordModel string
+
+	// Sensitivity is the default detection sensitivity from 0 to 1; higher
+	// values trigger more easily
+	Sensitivity float64
+}
+
+// ServiceInfo contains information about the wake-word service
+type ServiceInfo struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Status      string            `json:"status"`
+	Model       string            `json:"model"`
+	Keyword     string            `json:"keyword"`
+	Sensitivity float64           `json:"sensitivity"`
+	LastUpdated time.Time         `json:"last_updated"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// Detection is a single wake-word activation
+type Detection struct {
+	Keyword    string    `json:"keyword"`
+	Score      float64   `json:"score"`
+	Time       float64   `json:"time"` // Seconds since the stream started
+	DetectedAt time.Time `json:"detected_at"`
+}
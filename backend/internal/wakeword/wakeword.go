@@ -0,0 +1,371 @@
+package wakeword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/downloader"
+	"alice-backend/internal/events"
+	"alice-backend/internal/minilm"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// TypeDetected is the event type of wake-word detections
+const TypeDetected = "wakeword.detected"
+
+// Feature model files shared by all openWakeWord keyword models
+const (
+	melspectrogramFile = "melspectrogram.onnx"
+	embeddingFile      = "embedding_model.onnx"
+)
+
+// Service detects a wake word in 16kHz audio with openWakeWord models: a
+// mel spectrogram model and a speech embedding model produce features that a
+// small per-keyword classifier scores
+type Service struct {
+	mu          sync.RWMutex
+	ready       bool
+	config      *Config
+	info        *ServiceInfo
+	pipeline    *pipeline
+	sensitivity float64
+
+	// runtimeHeld is set while the service holds a reference on the shared ONNX Runtime
+	runtimeHeld bool
+
+	downloader *downloader.Downloader
+	detections *events.Bus
+}
+
+// NewService creates a new wake-word service
+func NewService(config *Config) *Service {
+	if config.Sensitivity <= 0 || config.Sensitivity >= 1 {
+		config.Sensitivity = 0.5
+	}
+
+	return &Service{
+		config:      config,
+		sensitivity: config.Sensitivity,
+		downloader:  downloader.NewDownloader(log.Default()),
+		detections:  events.NewBus(),
+		info: &ServiceInfo{
+			Name:        "openWakeWord",
+			Version:     "1.0.0",
+			Status:      "initializing",
+			LastUpdated: time.Now(),
+			Metadata:    make(map[string]string),
+		},
+	}
+}
+
+// Initialize downloads the models if needed and loads them
+func (s *Service) Initialize(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Println("Initializing wake-word service...")
+
+	if err := s.load(ctx); err != nil {
+		s.info.Status = "error"
+		return err
+	}
+
+	log.Printf("Wake-word service initialized (keyword: %s)", s.pipeline.keyword)
+	return nil
+}
+
+// Reload reloads the models so a newly downloaded one is picked up without
+// restarting the backend
+func (s *Service) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Println("Reloading wake-word models...")
+
+	if s.pipeline != nil {
+		s.pipeline.destroy()
+		s.pipeline = nil
+	}
+	s.ready = false
+
+	if err := s.load(ctx); err != nil {
+		s.info.Status = "error"
+		return err
+	}
+
+	log.Println("Wake-word models reloaded")
+	return nil
+}
+
+// load prepares the runtime and models; called with s.mu held
+func (s *Service) load(ctx context.Context) error {
+	if err := os.MkdirAll(s.config.ModelPath, 0o755); err != nil {
+		return err
+	}
+
+	if !s.runtimeHeld {
+		if err := minilm.AcquireRuntime(ctx, s.downloader); err != nil {
+			return fmt.Errorf("onnxruntime: %w", err)
+		}
+		s.runtimeHeld = true
+	}
+
+	// The catalog keyword model is the first file after the feature models,
+	// in artifact order
+	var melPath, embeddingPath, catalogKeywordPath string
+	for _, file := range s.ModelFiles() {
+		// Fetch keeps an existing file only if it passes verification
+		if err := s.downloader.Fetch(ctx, file, nil); err != nil {
+			return err
+		}
+		switch filepath.Base(file.DestPath) {
+		case melspectrogramFile:
+			melPath = file.DestPath
+		case embeddingFile:
+			embeddingPath = file.DestPath
+		default:
+			if catalogKeywordPath == "" {
+				catalogKeywordPath = file.DestPath
+			}
+		}
+	}
+	if melPath == "" || embeddingPath == "" {
+		return fmt.Errorf("wake-word catalog entry must provide %s and %s", melspectrogramFile, embeddingFile)
+	}
+
+	// A custom keyword model takes precedence over the catalog one
+	keywordPath := s.config.KeywordModel
+	if keywordPath == "" {
+		keywordPath = catalogKeywordPath
+	}
+	if keywordPath == "" {
+		return errors.New("no wake-word keyword model configured")
+	}
+
+	p, err := loadPipeline(melPath, embeddingPath, keywordPath)
+	if err != nil {
+		return err
+	}
+	s.pipeline = p
+
+	s.ready = true
+	s.info.Status = "ready"
+	s.info.Model = filepath.Base(keywordPath)
+	s.info.Keyword = p.keyword
+	s.info.LastUpdated = time.Now()
+	s.info.Metadata["feature_window"] = fmt.Sprint(p.featureWindow)
+	if s.config.KeywordModel != "" {
+		s.info.Metadata["keyword_source"] = "custom"
+		delete(s.info.Metadata, "custom_keyword")
+	} else {
+		s.info.Metadata["keyword_source"] = "catalog"
+		s.info.Metadata["custom_keyword"] = fmt.Sprintf("The catalog only has the keywords %s; "+
+			"to wake on another phrase such as \"hey alice\", set WAKEWORD_KEYWORD_MODEL to a custom openWakeWord model",
+			strings.Join(catalogKeywords(), ", "))
+	}
+	return nil
+}
+
+// catalogKeywords returns the names of the keyword models in the catalog
+func catalogKeywords() []string {
+	var names []string
+	for _, model := range catalog.Default().ServiceModels("wakeword") {
+		names = append(names, model.Name)
+	}
+	return names
+}
+
+// ModelFiles returns the files that make up the configured wake-word model
+func (s *Service) ModelFiles() []downloader.File {
+	model, ok := catalog.Default().DefaultModel("wakeword")
+	if s.config.Model != "" {
+		model, ok = catalog.Default().Model("wakeword", s.config.Model)
+	}
+	if !ok {
+		return nil
+	}
+
+	files := make([]downloader.File, 0, len(model.Files))
+	for _, artifact := range model.Files {
+		files = append(files, artifact.Download(s.config.ModelPath))
+	}
+	return files
+}
+
+// IsReady returns true if the service is ready
+func (s *Service) IsReady() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ready
+}
+
+// GetInfo returns service information
+func (s *Service) GetInfo() *ServiceInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info := *s.info
+	info.Sensitivity = s.sensitivity
+	info.LastUpdated = time.Now()
+	return &info
+}
+
+// Sensitivity returns the default detection sensitivity
+func (s *Service) Sensitivity() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sensitivity
+}
+
+// SetSensitivity changes the default sensitivity used by new detectors
+func (s *Service) SetSensitivity(sensitivity float64) error {
+	if sensitivity <= 0 || sensitivity >= 1 {
+		return fmt.Errorf("sensitivity must be between 0 and 1 (exclusive)")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sensitivity = sensitivity
+	return nil
+}
+
+// Subscribe returns a channel receiving the detections of every detector
+func (s *Service) Subscribe() (<-chan events.Event, func()) {
+	return s.detections.Subscribe()
+}
+
+// Shutdown releases the models and the ONNX Runtime
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pipeline != nil {
+		s.pipeline.destroy()
+		s.pipeline = nil
+	}
+
+	if s.runtimeHeld {
+		minilm.ReleaseRuntime()
+		s.runtimeHeld = false
+	}
+
+	s.ready = false
+	s.info.Status = "stopped"
+	s.info.LastUpdated = time.Now()
+
+	log.Println("Wake-word service shutdown completed")
+	return nil
+}
+
+// pipeline holds the three models of an openWakeWord detector
+type pipeline struct {
+	melspec    *onnxModel
+	embedding  *onnxModel
+	classifier *onnxModel
+	keyword    string
+
+	// melWindow is the number of mel frames per embedding, featureWindow the
+	// number of embeddings the classifier scores at once
+	melWindow     int
+	featureWindow int
+}
+
+func loadPipeline(melPath, embeddingPath, keywordPath string) (*pipeline, error) {
+	p := &pipeline{keyword: keywordName(keywordPath)}
+
+	var err error
+	if p.melspec, err = loadModel(melPath); err != nil {
+		return nil, fmt.Errorf("mel spectrogram model: %w", err)
+	}
+	if p.embedding, err = loadModel(embeddingPath); err != nil {
+		p.destroy()
+		return nil, fmt.Errorf("embedding model: %w", err)
+	}
+	if p.classifier, err = loadModel(keywordPath); err != nil {
+		p.destroy()
+		return nil, fmt.Errorf("keyword model: %w", err)
+	}
+
+	// Input shapes are [1, frames, bins, 1] and [1, embeddings, dim]
+	p.melWindow = p.embedding.dim(1, 76)
+	p.featureWindow = p.classifier.dim(1, 16)
+	return p, nil
+}
+
+func (p *pipeline) destroy() {
+	for _, m := range []*onnxModel{p.melspec, p.embedding, p.classifier} {
+		if m != nil {
+			m.session.Destroy()
+		}
+	}
+}
+
+// keywordName derives a keyword from a model file name, e.g.
+// hey_jarvis_v0.1.onnx becomes hey_jarvis
+func keywordName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if i := strings.LastIndex(name, "_v"); i > 0 && strings.Trim(name[i+2:], "0123456789.") == "" {
+		name = name[:i]
+	}
+	return name
+}
+
+// onnxModel is a model with a single float32 input and output
+type onnxModel struct {
+	session    *ort.DynamicAdvancedSession
+	inputShape ort.Shape
+}
+
+func loadModel(path string) (*onnxModel, error) {
+	inputs, outputs, err := ort.GetInputOutputInfo(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) != 1 || len(outputs) == 0 {
+		return nil, fmt.Errorf("expected a single input, got %d inputs and %d outputs", len(inputs), len(outputs))
+	}
+
+	session, err := ort.NewDynamicAdvancedSession(path, []string{inputs[0].Name}, []string{outputs[0].Name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &onnxModel{session: session, inputShape: inputs[0].Dimensions}, nil
+}
+
+// dim returns a fixed input dimension, or fallback if it is dynamic
+func (m *onnxModel) dim(i int, fallback int) int {
+	if i < len(m.inputShape) && m.inputShape[i] > 0 {
+		return int(m.inputShape[i])
+	}
+	return fallback
+}
+
+// run evaluates the model on one input tensor
+func (m *onnxModel) run(shape ort.Shape, data []float32) ([]float32, error) {
+	input, err := ort.NewTensor(shape, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create input tensor: %w", err)
+	}
+	defer input.Destroy()
+
+	outputs := []ort.Value{nil}
+	if err := m.session.Run([]ort.Value{input}, outputs); err != nil {
+		return nil, fmt.Errorf("ONNX inference failed: %w", err)
+	}
+	defer outputs[0].Destroy()
+
+	tensor, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return nil, errors.New("unexpected output type")
+	}
+	return append([]float32(nil), tensor.GetData()...), nil
+}
@@ -22,6 +22,29 @@ func convertAudioToSamples(audioData []byte) ([]float32, error) {
 	return samples, nil
 }
 
+// DecodePCM16 converts little-endian 16-bit PCM to float samples in [-1, 1]
+func DecodePCM16(audioData []byte) ([]float32, error) {
+	return convertAudioToSamples(audioData)
+}
+
+// EncodePCM16 converts float samples in [-1, 1] to little-endian 16-bit PCM
+func EncodePCM16(samples []float32) []byte {
+	audioData := make([]byte, len(samples)*2)
+	for i, sample := range samples {
+		// Clamp sample to [-1, 1] range
+		if sample > 1.0 {
+			sample = 1.0
+		} else if sample < -1.0 {
+			sample = -1.0
+		}
+
+		sample16 := int16(sample * 32767)
+		audioData[i*2] = byte(sample16)
+		audioData[i*2+1] = byte(sample16 >> 8)
+	}
+	return audioData
+}
+
 // createWAV creates a WAV file buffer from float32 samples
 func createWAV(samples []float32) ([]byte, error) {
 	const sampleRate = 16000
@@ -0,0 +1,137 @@
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// binaryCapabilities describes the installed whisper.cpp binaries. It is probed
+// once per process because running --help and nvidia-smi is slow.
+type binaryCapabilities struct {
+	Path       string // whisper CLI binary
+	ServerPath string // whisper-server binary next to the CLI, if present
+	OutputText bool   // CLI supports -otxt
+	OutputJSON bool   // CLI supports -oj
+	FullJSON   bool   // CLI supports -ojf (token-level details)
+	GPU        bool   // NVIDIA GPU with CUDA libraries available
+}
+
+// whisperBinaryNames lists CLI binary names in order of preference
+func whisperBinaryNames() []string {
+	names := []string{"whisper-cli", "whisper-command", "main", "whisper"}
+	if runtime.GOOS == "windows" {
+		for i, name := range names {
+			names[i] = name + ".exe"
+		}
+	}
+	return names
+}
+
+// findWhisperBinary returns the first whisper CLI binary found, preferring embedded assets
+func (s *STTService) findWhisperBinary() string {
+	embeddedBinaryPath := s.assetManager.GetBinaryPath("whisper")
+	if s.assetManager.IsAssetAvailable(embeddedBinaryPath) {
+		return embeddedBinaryPath
+	}
+
+	for _, name := range whisperBinaryNames() {
+		path := filepath.Join("bin", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// capabilities returns the cached binary capabilities, locating (and if needed
+// downloading) the whisper binary on first use
+func (s *STTService) capabilities(ctx context.Context) (*binaryCapabilities, error) {
+	s.capsMu.Lock()
+	defer s.capsMu.Unlock()
+
+	if s.caps != nil {
+		return s.caps, nil
+	}
+
+	whisperPath := s.findWhisperBinary()
+	if whisperPath == "" {
+		if err := s.downloadWhisperBinary(ctx); err != nil {
+			return nil, fmt.Errorf("no whisper binary found and download failed: %w", err)
+		}
+		whisperPath = s.findWhisperBinary()
+		if whisperPath == "" {
+			return nil, fmt.Errorf("no whisper binary found even after download attempt")
+		}
+	}
+
+	s.caps = probeCapabilities(whisperPath)
+	return s.caps, nil
+}
+
+// probeCapabilities inspects the whisper binary's help output and the GPU setup
+func probeCapabilities(whisperPath string) *binaryCapabilities {
+	caps := &binaryCapabilities{Path: whisperPath}
+
+	helpCmd := exec.Command(whisperPath, "--help")
+	helpCmd.Env = whisperEnv(whisperPath)
+	helpOutput, _ := helpCmd.CombinedOutput()
+	help := string(helpOutput)
+
+	caps.OutputText = strings.Contains(help, "-otxt")
+	caps.OutputJSON = strings.Contains(help, "-oj,") || strings.Contains(help, "--output-json")
+	caps.FullJSON = strings.Contains(help, "-ojf") || strings.Contains(help, "--output-json-full")
+
+	serverName := "whisper-server"
+	if runtime.GOOS == "windows" {
+		serverName += ".exe"
+	}
+	for _, dir := range []string{filepath.Dir(whisperPath), "bin"} {
+		path := filepath.Join(dir, serverName)
+		if _, err := os.Stat(path); err == nil {
+			caps.ServerPath = path
+			break
+		}
+	}
+
+	// GPU detection and configuration
+	hasGPU := hasNVIDIAGPU()
+	hasCUDALibs := hasCUDALibraries()
+	caps.GPU = hasGPU && hasCUDALibs
+
+	if !hasGPU {
+		log.Println("No NVIDIA GPU detected - using CPU mode")
+	} else if !hasCUDALibs {
+		log.Println("CUDA libraries not found - using CPU mode")
+	} else {
+		log.Println("NVIDIA GPU with CUDA libraries detected - using GPU acceleration")
+	}
+
+	log.Printf("Whisper binary %s: otxt=%v oj=%v ojf=%v server=%q gpu=%v",
+		whisperPath, caps.OutputText, caps.OutputJSON, caps.FullJSON, caps.ServerPath, caps.GPU)
+	return caps
+}
+
+// whisperEnv returns the environment for running a whisper binary, adding its
+// directory to LD_LIBRARY_PATH on Linux so bundled shared libraries are found
+func whisperEnv(binaryPath string) []string {
+	env := os.Environ()
+	if runtime.GOOS != "linux" {
+		return env
+	}
+
+	binDir := filepath.Dir(binaryPath)
+	ldLibraryPath := binDir
+	for _, e := range env {
+		if strings.HasPrefix(e, "LD_LIBRARY_PATH=") {
+			ldLibraryPath = binDir + ":" + strings.TrimPrefix(e, "LD_LIBRARY_PATH=")
+			break
+		}
+	}
+	return append(env, "LD_LIBRARY_PATH="+ldLibraryPath)
+}
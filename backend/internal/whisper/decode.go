@@ -0,0 +1,83 @@
+package whisper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Sample rates accepted for uploaded audio
+const (
+	minSampleRate = 4000
+	maxSampleRate = 384000
+)
+
+// ErrUnsupportedAudio is returned for audio formats or codecs that cannot be decoded
+var ErrUnsupportedAudio = errors.New("unsupported audio format")
+
+// decodedAudio is interleaved PCM audio normalized to [-1, 1]
+type decodedAudio struct {
+	samples    []float32
+	channels   int
+	sampleRate int
+}
+
+// DetectAudioFormat identifies an encoded audio file by its magic bytes. It returns
+// "wav", "flac" or "ogg", or "" for data without a recognized container (raw PCM).
+func DetectAudioFormat(data []byte) string {
+	switch {
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		return "wav"
+	case bytes.HasPrefix(data, []byte("fLaC")):
+		return "flac"
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return "ogg"
+	}
+	return ""
+}
+
+// DecodeAudio decodes a WAV, FLAC or Ogg file into mono float32 samples at the
+// 16kHz rate whisper expects
+func DecodeAudio(data []byte) ([]float32, error) {
+	var audio *decodedAudio
+	var err error
+
+	switch DetectAudioFormat(data) {
+	case "wav":
+		audio, err = decodeWAV(data)
+	case "flac":
+		audio, err = decodeFLAC(data)
+	case "ogg":
+		audio, err = decodeOgg(data)
+	default:
+		return nil, fmt.Errorf("%w: unrecognized container", ErrUnsupportedAudio)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Resampling allocates in proportion to 16000/sampleRate, so an absurdly
+	// low rate in a forged header could exhaust memory
+	if audio.channels < 1 || audio.sampleRate < minSampleRate || audio.sampleRate > maxSampleRate {
+		return nil, fmt.Errorf("invalid audio: %d channels at %d Hz", audio.channels, audio.sampleRate)
+	}
+
+	return Resample(downmix(audio.samples, audio.channels), audio.sampleRate, 16000), nil
+}
+
+// downmix averages interleaved channels into a single mono channel
+func downmix(samples []float32, channels int) []float32 {
+	if channels == 1 {
+		return samples
+	}
+
+	mono := make([]float32, len(samples)/channels)
+	for i := range mono {
+		var sum float32
+		for c := 0; c < channels; c++ {
+			sum += samples[i*channels+c]
+		}
+		mono[i] = sum / float32(channels)
+	}
+	return mono
+}
@@ -0,0 +1,415 @@
+package whisper
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// errFLACTruncated marks a frame cut off by the end of the data
+var errFLACTruncated = errors.New("truncated FLAC frame")
+
+// flacStreamInfo holds the STREAMINFO fields needed for decoding
+type flacStreamInfo struct {
+	sampleRate    int
+	channels      int
+	bitsPerSample int
+}
+
+// decodeFLAC decodes a native FLAC stream ("fLaC" followed by metadata blocks and frames)
+func decodeFLAC(data []byte) (*decodedAudio, error) {
+	info, offset, err := parseFLACMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFLACFrames(data[offset:], info)
+}
+
+// parseFLACMetadata reads the metadata blocks and returns where the first frame starts
+func parseFLACMetadata(data []byte) (*flacStreamInfo, int, error) {
+	if len(data) < 4 || string(data[:4]) != "fLaC" {
+		return nil, 0, fmt.Errorf("invalid FLAC stream: missing signature")
+	}
+
+	var info *flacStreamInfo
+	var err error
+	offset := 4
+	for {
+		if offset+4 > len(data) {
+			return nil, 0, fmt.Errorf("invalid FLAC stream: truncated metadata")
+		}
+		header := data[offset]
+		length := int(data[offset+1])<<16 | int(data[offset+2])<<8 | int(data[offset+3])
+		offset += 4
+		if offset+length > len(data) {
+			return nil, 0, fmt.Errorf("invalid FLAC stream: truncated metadata")
+		}
+
+		if header&0x7F == 0 {
+			if info, err = parseFLACStreamInfo(data[offset : offset+length]); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		offset += length
+		if header&0x80 != 0 {
+			break
+		}
+	}
+
+	if info == nil {
+		return nil, 0, fmt.Errorf("invalid FLAC stream: missing STREAMINFO")
+	}
+	return info, offset, nil
+}
+
+// parseFLACStreamInfo decodes the body of a STREAMINFO metadata block
+func parseFLACStreamInfo(block []byte) (*flacStreamInfo, error) {
+	if len(block) < 18 {
+		return nil, fmt.Errorf("invalid FLAC STREAMINFO block")
+	}
+	// Bytes 10-13: 20-bit sample rate, 3-bit channels-1, 5-bit bits per sample-1
+	packed := binary.BigEndian.Uint32(block[10:14])
+	return &flacStreamInfo{
+		sampleRate:    int(packed >> 12),
+		channels:      int(packed>>9&0x7) + 1,
+		bitsPerSample: int(packed>>4&0x1F) + 1,
+	}, nil
+}
+
+// decodeFLACFrames decodes consecutive FLAC frames into interleaved samples
+func decodeFLACFrames(data []byte, info *flacStreamInfo) (*decodedAudio, error) {
+	audio := &decodedAudio{channels: info.channels, sampleRate: info.sampleRate}
+
+	for offset := 0; offset+2 <= len(data); {
+		// Frames start with the 14-bit sync code 0b11111111111110
+		if data[offset] != 0xFF || data[offset+1]&0xFE != 0xF8 {
+			offset++
+			continue
+		}
+
+		n, err := decodeFLACFrame(data[offset:], info, audio)
+		if errors.Is(err, errFLACTruncated) {
+			break
+		}
+		if err != nil {
+			if len(audio.samples) == 0 {
+				return nil, err
+			}
+			// Skip a corrupt frame and resynchronize on the next sync code
+			offset++
+			continue
+		}
+		offset += n
+	}
+
+	if len(audio.samples) == 0 {
+		return nil, fmt.Errorf("FLAC stream contains no audio frames")
+	}
+	return audio, nil
+}
+
+var flacSampleRates = [12]int{0, 88200, 176400, 192000, 8000, 16000, 22050, 24000, 32000, 44100, 48000, 96000}
+
+var flacSampleSizes = [8]int{0, 8, 12, 0, 16, 20, 24, 32}
+
+// decodeFLACFrame decodes one frame, appends its samples to audio and returns its length in bytes
+func decodeFLACFrame(data []byte, info *flacStreamInfo, audio *decodedAudio) (n int, err error) {
+	defer func() {
+		// The bit reader panics when it runs past the end of the data
+		if r := recover(); r != nil {
+			if r != errFLACTruncated {
+				panic(r)
+			}
+			n, err = 0, errFLACTruncated
+		}
+	}()
+
+	br := &bitReader{data: data}
+	br.skip(16) // Sync code, reserved bit and blocking strategy
+
+	blockSizeCode := br.read(4)
+	sampleRateCode := br.read(4)
+	channelAssignment := int(br.read(4))
+	sampleSizeCode := br.read(3)
+	br.skip(1)
+
+	// Frame or sample number, UTF-8 style variable length
+	first := br.read(8)
+	for mask := uint64(0x80); first&mask != 0 && mask > 1; mask >>= 1 {
+		if mask != 0x80 {
+			br.skip(8)
+		}
+	}
+
+	var blockSize int
+	switch {
+	case blockSizeCode == 1:
+		blockSize = 192
+	case blockSizeCode >= 2 && blockSizeCode <= 5:
+		blockSize = 576 << (blockSizeCode - 2)
+	case blockSizeCode == 6:
+		blockSize = int(br.read(8)) + 1
+	case blockSizeCode == 7:
+		blockSize = int(br.read(16)) + 1
+	case blockSizeCode >= 8:
+		blockSize = 256 << (blockSizeCode - 8)
+	default:
+		return 0, fmt.Errorf("invalid FLAC block size code")
+	}
+
+	sampleRate := info.sampleRate
+	switch {
+	case sampleRateCode >= 1 && sampleRateCode <= 11:
+		sampleRate = flacSampleRates[sampleRateCode]
+	case sampleRateCode == 12:
+		sampleRate = int(br.read(8)) * 1000
+	case sampleRateCode == 13:
+		sampleRate = int(br.read(16))
+	case sampleRateCode == 14:
+		sampleRate = int(br.read(16)) * 10
+	case sampleRateCode == 15:
+		return 0, fmt.Errorf("invalid FLAC sample rate code")
+	}
+	if sampleRate != info.sampleRate {
+		return 0, fmt.Errorf("%w: FLAC sample rate changes mid-stream", ErrUnsupportedAudio)
+	}
+
+	bitsPerSample := info.bitsPerSample
+	if sampleSizeCode != 0 {
+		bitsPerSample = flacSampleSizes[sampleSizeCode]
+		if bitsPerSample == 0 {
+			return 0, fmt.Errorf("invalid FLAC sample size code")
+		}
+	}
+
+	channels := channelAssignment + 1
+	if channelAssignment >= 8 {
+		if channelAssignment > 10 {
+			return 0, fmt.Errorf("invalid FLAC channel assignment %d", channelAssignment)
+		}
+		channels = 2
+	}
+	if channels != info.channels {
+		return 0, fmt.Errorf("%w: FLAC channel count changes mid-stream", ErrUnsupportedAudio)
+	}
+
+	br.skip(8) // CRC-8 of the header
+
+	subframes := make([][]int64, channels)
+	for ch := range subframes {
+		bps := bitsPerSample
+		// The side channel carries one extra bit
+		if (channelAssignment == 8 && ch == 1) || (channelAssignment == 9 && ch == 0) || (channelAssignment == 10 && ch == 1) {
+			bps++
+		}
+		subframes[ch], err = decodeFLACSubframe(br, blockSize, bps)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	switch channelAssignment {
+	case 8: // left/side
+		for i := range subframes[1] {
+			subframes[1][i] = subframes[0][i] - subframes[1][i]
+		}
+	case 9: // side/right
+		for i := range subframes[0] {
+			subframes[0][i] += subframes[1][i]
+		}
+	case 10: // mid/side
+		for i := range subframes[0] {
+			mid, side := subframes[0][i]<<1|subframes[1][i]&1, subframes[1][i]
+			subframes[0][i] = (mid + side) >> 1
+			subframes[1][i] = (mid - side) >> 1
+		}
+	}
+
+	br.align()
+	br.skip(16) // CRC-16 of the frame
+
+	scale := float32(int64(1) << (bitsPerSample - 1))
+	for i := 0; i < blockSize; i++ {
+		for ch := 0; ch < channels; ch++ {
+			audio.samples = append(audio.samples, float32(subframes[ch][i])/scale)
+		}
+	}
+
+	return br.pos / 8, nil
+}
+
+// decodeFLACSubframe decodes the samples of one channel
+func decodeFLACSubframe(br *bitReader, blockSize, bps int) ([]int64, error) {
+	br.skip(1) // Zero padding
+	kind := int(br.read(6))
+
+	wasted := 0
+	if br.read(1) == 1 {
+		wasted = br.unary() + 1
+		bps -= wasted
+	}
+
+	samples := make([]int64, blockSize)
+	switch {
+	case kind == 0: // CONSTANT
+		v := br.signed(bps)
+		for i := range samples {
+			samples[i] = v
+		}
+	case kind == 1: // VERBATIM
+		for i := range samples {
+			samples[i] = br.signed(bps)
+		}
+	case kind >= 8 && kind <= 12: // FIXED
+		order := kind - 8
+		for i := 0; i < order; i++ {
+			samples[i] = br.signed(bps)
+		}
+		if err := decodeFLACResidual(br, samples, order); err != nil {
+			return nil, err
+		}
+		restoreFixed(samples, order)
+	case kind >= 32: // LPC
+		order := kind - 31
+		for i := 0; i < order; i++ {
+			samples[i] = br.signed(bps)
+		}
+		precision := int(br.read(4)) + 1
+		if precision == 16 {
+			return nil, fmt.Errorf("invalid FLAC LPC precision")
+		}
+		shift := br.signed(5)
+		if shift < 0 {
+			return nil, fmt.Errorf("invalid FLAC LPC shift")
+		}
+		coeffs := make([]int64, order)
+		for i := range coeffs {
+			coeffs[i] = br.signed(precision)
+		}
+		if err := decodeFLACResidual(br, samples, order); err != nil {
+			return nil, err
+		}
+		for i := order; i < blockSize; i++ {
+			var sum int64
+			for j, c := range coeffs {
+				sum += c * samples[i-1-j]
+			}
+			samples[i] += sum >> shift
+		}
+	default:
+		return nil, fmt.Errorf("invalid FLAC subframe type %d", kind)
+	}
+
+	if wasted > 0 {
+		for i := range samples {
+			samples[i] <<= wasted
+		}
+	}
+	return samples, nil
+}
+
+// decodeFLACResidual reads Rice-coded residuals into samples[order:]
+func decodeFLACResidual(br *bitReader, samples []int64, order int) error {
+	method := br.read(2)
+	if method > 1 {
+		return fmt.Errorf("invalid FLAC residual coding method %d", method)
+	}
+	paramBits, escape := 4, uint64(15)
+	if method == 1 {
+		paramBits, escape = 5, 31
+	}
+
+	partitionOrder := int(br.read(4))
+	partitions := 1 << partitionOrder
+	partitionSize := len(samples) >> partitionOrder
+	if partitionSize < order || partitionSize<<partitionOrder != len(samples) {
+		return fmt.Errorf("invalid FLAC partition order %d", partitionOrder)
+	}
+
+	i := order
+	for p := 0; p < partitions; p++ {
+		end := (p + 1) * partitionSize
+		param := br.read(paramBits)
+		if param == escape {
+			bits := int(br.read(5))
+			for ; i < end; i++ {
+				samples[i] = br.signed(bits)
+			}
+			continue
+		}
+		for ; i < end; i++ {
+			v := uint64(br.unary())<<param | br.read(int(param))
+			samples[i] = int64(v>>1) ^ -int64(v&1)
+		}
+	}
+	return nil
+}
+
+// restoreFixed applies FLAC's fixed polynomial predictors in place
+func restoreFixed(s []int64, order int) {
+	for i := order; i < len(s); i++ {
+		switch order {
+		case 1:
+			s[i] += s[i-1]
+		case 2:
+			s[i] += 2*s[i-1] - s[i-2]
+		case 3:
+			s[i] += 3*s[i-1] - 3*s[i-2] + s[i-3]
+		case 4:
+			s[i] += 4*s[i-1] - 6*s[i-2] + 4*s[i-3] - s[i-4]
+		}
+	}
+}
+
+// bitReader reads big-endian bit fields. Reading past the end panics with
+// errFLACTruncated, which decodeFLACFrame turns into an error.
+type bitReader struct {
+	data []byte
+	pos  int // In bits
+}
+
+func (br *bitReader) read(n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	if br.pos+n > len(br.data)*8 {
+		panic(errFLACTruncated)
+	}
+	var v uint64
+	for n > 0 {
+		byteIdx, bitIdx := br.pos/8, br.pos%8
+		take := min(8-bitIdx, n)
+		bits := uint64(br.data[byteIdx]>>(8-bitIdx-take)) & (1<<take - 1)
+		v = v<<take | bits
+		br.pos += take
+		n -= take
+	}
+	return v
+}
+
+// signed reads an n-bit two's complement value
+func (br *bitReader) signed(n int) int64 {
+	if n == 0 {
+		return 0
+	}
+	v := br.read(n)
+	return int64(v<<(64-n)) >> (64 - n)
+}
+
+// unary counts zero bits up to the next one bit
+func (br *bitReader) unary() int {
+	count := 0
+	for br.read(1) == 0 {
+		count++
+	}
+	return count
+}
+
+func (br *bitReader) skip(n int) {
+	br.read(n)
+}
+
+func (br *bitReader) align() {
+	br.pos = (br.pos + 7) &^ 7
+}
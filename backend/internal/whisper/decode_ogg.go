@@ -0,0 +1,218 @@
+package whisper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/jfreymuth/oggvorbis"
+	"github.com/pion/opus"
+)
+
+// opusSampleRate is the rate Opus streams are decoded at. Opus decoders can
+// output 16kHz directly, which spares resampling for whisper.
+const opusSampleRate = 16000
+
+// decodeOgg decodes the first logical stream of an Ogg file carrying Opus,
+// Vorbis or FLAC
+func decodeOgg(data []byte) (*decodedAudio, error) {
+	packets, granule, err := readOggPackets(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("invalid Ogg file: no packets")
+	}
+
+	head := packets[0]
+	switch {
+	case bytes.HasPrefix(head, []byte("\x7fFLAC")):
+		return decodeOggFLAC(packets)
+	case bytes.HasPrefix(head, []byte("OpusHead")):
+		return decodeOggOpus(packets, granule)
+	case bytes.HasPrefix(head, []byte("\x01vorbis")):
+		return decodeOggVorbis(data)
+	}
+	return nil, fmt.Errorf("%w: unknown Ogg codec", ErrUnsupportedAudio)
+}
+
+// readOggPackets reassembles the packets of the first logical stream in an Ogg
+// file and returns the last granule position of that stream, or -1 if no page
+// set one
+func readOggPackets(data []byte) ([][]byte, int64, error) {
+	var (
+		packets [][]byte
+		current []byte
+		serial  uint32
+		started bool
+		granule int64 = -1
+	)
+
+	for offset := 0; offset+27 <= len(data); {
+		if !bytes.Equal(data[offset:offset+4], []byte("OggS")) {
+			return nil, 0, fmt.Errorf("invalid Ogg page at offset %d", offset)
+		}
+
+		pageSerial := binary.LittleEndian.Uint32(data[offset+14 : offset+18])
+		numSegments := int(data[offset+26])
+		if offset+27+numSegments > len(data) {
+			break
+		}
+		lacing := data[offset+27 : offset+27+numSegments]
+		body := offset + 27 + numSegments
+
+		pageSize := 0
+		for _, l := range lacing {
+			pageSize += int(l)
+		}
+		if body+pageSize > len(data) {
+			break // Truncated final page
+		}
+
+		if !started {
+			serial, started = pageSerial, true
+		}
+		if pageSerial == serial {
+			// -1 marks a page on which no packet ends
+			if g := int64(binary.LittleEndian.Uint64(data[offset+6 : offset+14])); g >= 0 {
+				granule = g
+			}
+
+			// A lacing value below 255 ends a packet; 255 continues it
+			pos := body
+			for _, l := range lacing {
+				current = append(current, data[pos:pos+int(l)]...)
+				pos += int(l)
+				if l < 255 {
+					packets = append(packets, current)
+					current = nil
+				}
+			}
+		}
+
+		offset = body + pageSize
+	}
+
+	return packets, granule, nil
+}
+
+// decodeOggFLAC decodes FLAC carried in Ogg: the first packet holds the mapping
+// header and STREAMINFO, then metadata packets, then one frame per packet
+func decodeOggFLAC(packets [][]byte) (*decodedAudio, error) {
+	head := packets[0]
+	// 0x7F "FLAC", version (2 bytes), header packet count (2 bytes), "fLaC", block header (4 bytes)
+	if len(head) < 17 || string(head[9:13]) != "fLaC" {
+		return nil, fmt.Errorf("invalid Ogg FLAC header")
+	}
+	info, err := parseFLACStreamInfo(head[17:])
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []byte
+	for _, packet := range packets[1:] {
+		// Metadata packets have a block header rather than a frame sync code
+		if len(packet) < 2 || packet[0] != 0xFF || packet[1]&0xFE != 0xF8 {
+			continue
+		}
+		frames = append(frames, packet...)
+	}
+	return decodeFLACFrames(frames, info)
+}
+
+// opusHead is the identification header of an Ogg Opus stream
+type opusHead struct {
+	channels      int
+	preSkip       int
+	outputGain    int16
+	mappingFamily byte
+}
+
+// parseOpusHead parses an OpusHead packet (RFC 7845 section 5.1)
+func parseOpusHead(packet []byte) (*opusHead, error) {
+	// "OpusHead", version, channels, pre-skip (2 bytes), input rate (4 bytes),
+	// output gain (2 bytes), channel mapping family
+	if len(packet) < 19 || string(packet[:8]) != "OpusHead" {
+		return nil, fmt.Errorf("invalid OpusHead: too short")
+	}
+	if packet[8]>>4 != 0 {
+		return nil, fmt.Errorf("%w: OpusHead version %d", ErrUnsupportedAudio, packet[8])
+	}
+	return &opusHead{
+		channels:      int(packet[9]),
+		preSkip:       int(binary.LittleEndian.Uint16(packet[10:12])),
+		outputGain:    int16(binary.LittleEndian.Uint16(packet[16:18])),
+		mappingFamily: packet[18],
+	}, nil
+}
+
+// decodeOggOpus decodes Opus carried in Ogg (RFC 7845): an OpusHead packet, an
+// OpusTags packet, then audio packets. The decoder's start-up samples (the
+// pre-skip) and the padding after the final granule position are dropped.
+func decodeOggOpus(packets [][]byte, granule int64) (*decodedAudio, error) {
+	head, err := parseOpusHead(packets[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) < 2 {
+		return nil, fmt.Errorf("invalid Ogg Opus file: missing OpusTags")
+	}
+
+	// Only mono and stereo in a single stream; multichannel layouts
+	// (families 1, 2 and 255) need a multistream decoder
+	channels := head.channels
+	if head.mappingFamily != 0 {
+		return nil, fmt.Errorf("%w: Opus channel mapping family %d", ErrUnsupportedAudio, head.mappingFamily)
+	}
+	if channels < 1 || channels > 2 {
+		return nil, fmt.Errorf("invalid OpusHead: %d channels in mapping family 0", channels)
+	}
+
+	decoder, err := opus.NewDecoderWithOutput(opusSampleRate, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Opus decoder: %w", err)
+	}
+
+	// Packets hold at most 120ms of audio
+	frame := make([]float32, opusSampleRate*120/1000*channels)
+	var samples []float32
+	for i, packet := range packets[2:] {
+		n, err := decoder.DecodeToFloat32(packet, frame)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Opus packet %d: %w", i, err)
+		}
+		samples = append(samples, frame[:n*channels]...)
+	}
+
+	// Granule positions and the pre-skip count 48kHz samples
+	const scale = 48000 / opusSampleRate
+	skip := min(head.preSkip/scale*channels, len(samples))
+	end := len(samples)
+	if granule >= 0 {
+		end = min(end, int(granule/scale)*channels)
+	}
+	if end < skip {
+		end = skip
+	}
+	samples = samples[skip:end]
+
+	// The output gain is in Q7.8 dB
+	if head.outputGain != 0 {
+		gain := float32(math.Pow(10, float64(head.outputGain)/(20*256)))
+		for i := range samples {
+			samples[i] *= gain
+		}
+	}
+
+	return &decodedAudio{samples: samples, channels: channels, sampleRate: opusSampleRate}, nil
+}
+
+// decodeOggVorbis decodes an Ogg Vorbis file
+func decodeOggVorbis(data []byte) (*decodedAudio, error) {
+	samples, format, err := oggvorbis.ReadAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ogg Vorbis file: %w", err)
+	}
+	return &decodedAudio{samples: samples, channels: format.Channels, sampleRate: format.SampleRate}, nil
+}
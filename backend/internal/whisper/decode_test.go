@@ -0,0 +1,411 @@
+package whisper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+// sineWave returns n frames of a 440Hz tone at half scale, each channel
+// slightly out of phase so a broken downmix or channel order shows up
+func sineWave(n, channels, rate int) []float32 {
+	samples := make([]float32, n*channels)
+	for i := 0; i < n; i++ {
+		for c := 0; c < channels; c++ {
+			phase := 2*math.Pi*440*float64(i)/float64(rate) + float64(c)*0.3
+			samples[i*channels+c] = float32(0.5 * math.Sin(phase))
+		}
+	}
+	return samples
+}
+
+// expected is what DecodeAudio should return for losslessly stored samples
+func expected(samples []float32, channels, rate int) []float32 {
+	return Resample(downmix(samples, channels), rate, 16000)
+}
+
+// wavFile encodes samples as a RIFF/WAVE file
+func wavFile(format uint16, bits, channels, rate int, extensible bool, samples []float32) []byte {
+	var pcm bytes.Buffer
+	for _, s := range samples {
+		switch {
+		case format == wavFormatPCM && bits == 16:
+			binary.Write(&pcm, binary.LittleEndian, int16(s*32767))
+		case format == wavFormatPCM && bits == 24:
+			v := int32(s * 8388607)
+			pcm.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16)})
+		case format == wavFormatFloat && bits == 32:
+			binary.Write(&pcm, binary.LittleEndian, s)
+		}
+	}
+
+	fmtChunk := new(bytes.Buffer)
+	tag := format
+	if extensible {
+		tag = wavFormatExtensible
+	}
+	blockAlign := channels * bits / 8
+	for _, field := range []any{tag, uint16(channels), uint32(rate), uint32(rate * blockAlign), uint16(blockAlign), uint16(bits)} {
+		binary.Write(fmtChunk, binary.LittleEndian, field)
+	}
+	if extensible {
+		// cbSize, valid bits, channel mask, then the sub-format GUID
+		for _, field := range []any{uint16(22), uint16(bits), uint32(0), format} {
+			binary.Write(fmtChunk, binary.LittleEndian, field)
+		}
+		fmtChunk.Write([]byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71})
+	}
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	binary.Write(&out, binary.LittleEndian, uint32(4+8+fmtChunk.Len()+8+pcm.Len()))
+	out.WriteString("WAVEfmt ")
+	binary.Write(&out, binary.LittleEndian, uint32(fmtChunk.Len()))
+	out.Write(fmtChunk.Bytes())
+	out.WriteString("data")
+	binary.Write(&out, binary.LittleEndian, uint32(pcm.Len()))
+	out.Write(pcm.Bytes())
+	return out.Bytes()
+}
+
+// bitWriter writes big-endian bit fields, as FLAC stores them
+type bitWriter struct {
+	buf  []byte
+	bits int
+}
+
+func (w *bitWriter) write(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.bits%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if v>>i&1 == 1 {
+			w.buf[len(w.buf)-1] |= 0x80 >> (w.bits % 8)
+		}
+		w.bits++
+	}
+}
+
+func (w *bitWriter) align() {
+	w.bits = len(w.buf) * 8
+}
+
+// flacStreamInfoBlock returns a STREAMINFO block body
+func flacStreamInfoBlock(channels, rate, bits, total int) []byte {
+	w := &bitWriter{}
+	w.write(flacTestBlockSize, 16)
+	w.write(flacTestBlockSize, 16)
+	w.write(0, 24) // Frame sizes unknown
+	w.write(0, 24)
+	w.write(uint64(rate), 20)
+	w.write(uint64(channels-1), 3)
+	w.write(uint64(bits-1), 5)
+	w.write(uint64(total), 36)
+	w.write(0, 64) // MD5 not computed
+	w.write(0, 64)
+	return w.buf
+}
+
+const flacTestBlockSize = 1024
+
+// flacFrames encodes 16-bit samples as FLAC frames with verbatim subframes.
+// Stereo uses left/side decorrelation so the side channel's extra bit is
+// exercised.
+func flacFrames(samples []float32, channels int) [][]byte {
+	var frames [][]byte
+	total := len(samples) / channels
+	for number, start := 0, 0; start < total; number, start = number+1, start+flacTestBlockSize {
+		block := min(flacTestBlockSize, total-start)
+		assignment := uint64(channels - 1)
+		if channels == 2 {
+			assignment = 8
+		}
+
+		w := &bitWriter{}
+		w.write(0x3FFE, 14) // Sync code
+		w.write(0, 2)       // Reserved, fixed block size
+		w.write(7, 4)       // Block size in a 16-bit field after the header
+		w.write(0, 4)       // Sample rate from STREAMINFO
+		w.write(assignment, 4)
+		w.write(0, 3) // Sample size from STREAMINFO
+		w.write(0, 1)
+		w.write(uint64(number), 8) // Frame numbers below 128 fit one byte
+		w.write(uint64(block-1), 16)
+		w.write(uint64(crc8(w.buf)), 8)
+
+		value := func(i, c int) int64 { return int64(samples[(start+i)*channels+c] * 32767) }
+		for c := 0; c < channels; c++ {
+			bps := 16
+			if assignment == 8 && c == 1 {
+				bps = 17
+			}
+			w.write(0, 1) // Padding
+			w.write(1, 6) // Verbatim
+			w.write(0, 1) // No wasted bits
+			for i := 0; i < block; i++ {
+				v := value(i, c)
+				if assignment == 8 && c == 1 {
+					v = value(i, 0) - value(i, 1)
+				}
+				w.write(uint64(v)&(1<<bps-1), bps)
+			}
+		}
+		w.align()
+		w.write(uint64(crc16(w.buf)), 16)
+		frames = append(frames, w.buf)
+	}
+	return frames
+}
+
+// flacFile encodes samples as a native FLAC stream
+func flacFile(samples []float32, channels, rate int) []byte {
+	info := flacStreamInfoBlock(channels, rate, 16, len(samples)/channels)
+	out := []byte("fLaC")
+	out = append(out, 0x80, 0, 0, byte(len(info))) // Last block, STREAMINFO
+	out = append(out, info...)
+	for _, frame := range flacFrames(samples, channels) {
+		out = append(out, frame...)
+	}
+	return out
+}
+
+// oggFLACFile wraps FLAC frames in Ogg, one packet per page
+func oggFLACFile(samples []float32, channels, rate int) []byte {
+	info := flacStreamInfoBlock(channels, rate, 16, len(samples)/channels)
+	head := []byte("\x7fFLAC\x01\x00\x00\x00fLaC")
+	head = append(head, 0x80, 0, 0, byte(len(info)))
+	head = append(head, info...)
+
+	var out []byte
+	out = appendOggPage(out, head, 0, 0x02)
+	for i, frame := range flacFrames(samples, channels) {
+		out = appendOggPage(out, frame, uint32(i+1), 0)
+	}
+	return out
+}
+
+// appendOggPage appends a page holding one packet
+func appendOggPage(out, packet []byte, sequence uint32, headerType byte) []byte {
+	var lacing []byte
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			lacing = append(lacing, byte(n))
+			break
+		}
+		lacing = append(lacing, 255)
+	}
+
+	page := []byte("OggS\x00")
+	page = append(page, headerType)
+	page = binary.LittleEndian.AppendUint64(page, 0) // Granule position
+	page = binary.LittleEndian.AppendUint32(page, 1) // Serial
+	page = binary.LittleEndian.AppendUint32(page, sequence)
+	page = binary.LittleEndian.AppendUint32(page, 0) // CRC, filled in below
+	page = append(page, byte(len(lacing)))
+	page = append(page, lacing...)
+	page = append(page, packet...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+	return append(out, page...)
+}
+
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc ^= uint32(b) << 24
+		for i := 0; i < 8; i++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func TestDecodeAudioLossless(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels int
+		rate     int
+		encode   func(samples []float32, channels, rate int) []byte
+		format   string
+		maxErr   float64
+	}{
+		{"wav pcm16 mono", 1, 16000, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatPCM, 16, c, r, false, s)
+		}, "wav", 1e-4},
+		{"wav pcm16 stereo 44.1k", 2, 44100, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatPCM, 16, c, r, false, s)
+		}, "wav", 1e-4},
+		{"wav pcm24 mono 48k", 1, 48000, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatPCM, 24, c, r, false, s)
+		}, "wav", 1e-6},
+		{"wav float32 stereo", 2, 22050, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatFloat, 32, c, r, false, s)
+		}, "wav", 1e-6},
+		{"wav extensible pcm16", 2, 16000, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatPCM, 16, c, r, true, s)
+		}, "wav", 1e-4},
+		{"wav extensible float32", 1, 8000, func(s []float32, c, r int) []byte {
+			return wavFile(wavFormatFloat, 32, c, r, true, s)
+		}, "wav", 1e-6},
+		{"flac mono", 1, 16000, flacFile, "flac", 1e-4},
+		{"flac stereo 44.1k", 2, 44100, flacFile, "flac", 1e-4},
+		{"ogg flac stereo", 2, 16000, oggFLACFile, "ogg", 1e-4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A length that isn't a multiple of the FLAC block size
+			samples := sineWave(tt.rate/2+123, tt.channels, tt.rate)
+			data := tt.encode(samples, tt.channels, tt.rate)
+
+			if got := DetectAudioFormat(data); got != tt.format {
+				t.Fatalf("DetectAudioFormat = %q, want %q", got, tt.format)
+			}
+
+			got, err := DecodeAudio(data)
+			if err != nil {
+				t.Fatalf("DecodeAudio failed: %v", err)
+			}
+			want := expected(samples, tt.channels, tt.rate)
+			if len(got) != len(want) {
+				t.Fatalf("decoded %d samples, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if d := math.Abs(float64(got[i] - want[i])); d > tt.maxErr {
+					t.Fatalf("sample %d is %f, want %f", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeAudioOpus(t *testing.T) {
+	// One second of the sineWave tone at 16kHz, encoded as 50 packets of 20ms
+	// with the usual pre-skip of 312 samples at 48kHz
+	const packets, preSkip = 50, 312
+
+	for _, tt := range []struct {
+		file     string
+		channels int
+	}{
+		{"testdata/opus-mono-16000.opus", 1},
+		{"testdata/opus-stereo-16000.opus", 2},
+	} {
+		data, err := os.ReadFile(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeAudio(data)
+		if err != nil {
+			t.Fatalf("%d channels: DecodeAudio failed: %v", tt.channels, err)
+		}
+
+		// Everything up to the final granule position, minus the pre-skip
+		want := (packets*960 - preSkip) / 3
+		if len(got) != want {
+			t.Errorf("%d channels: decoded %d samples, want %d", tt.channels, len(got), want)
+		}
+
+		// Lossy, so compare the level of the tone rather than the samples
+		var sum float64
+		for _, s := range got[len(got)/4 : len(got)*3/4] {
+			sum += float64(s) * float64(s)
+		}
+		if rms := math.Sqrt(sum / float64(len(got)/2)); rms < 0.25 || rms > 0.45 {
+			t.Errorf("%d channels: RMS is %.3f, want about 0.35", tt.channels, rms)
+		}
+	}
+}
+
+func TestDecodeAudioVorbis(t *testing.T) {
+	// One second of mono audio at 44.1kHz, from github.com/jfreymuth/oggvorbis
+	data, err := os.ReadFile("testdata/vorbis-mono-44100.ogg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DecodeAudio(data)
+	if err != nil {
+		t.Fatalf("DecodeAudio failed: %v", err)
+	}
+	if len(got) < 15990 || len(got) > 16010 {
+		t.Errorf("decoded %d samples, want about 16000", len(got))
+	}
+
+	var peak float32
+	for _, s := range got {
+		peak = max(peak, float32(math.Abs(float64(s))))
+	}
+	if peak < 0.1 {
+		t.Errorf("decoded audio is silent (peak %f)", peak)
+	}
+}
+
+func TestDecodeAudioRejects(t *testing.T) {
+	samples := sineWave(100, 1, 16000)
+	tests := []struct {
+		name        string
+		data        []byte
+		unsupported bool
+	}{
+		{"unknown container", []byte("ID3\x03\x00 not audio"), true},
+		{"wav at 1 Hz", wavFile(wavFormatPCM, 16, 1, 1, false, samples), false},
+		{"wav above 384 kHz", wavFile(wavFormatPCM, 16, 1, 400000, false, samples), false},
+		{"wav 12-bit", func() []byte {
+			data := wavFile(wavFormatPCM, 16, 1, 16000, false, samples)
+			binary.LittleEndian.PutUint16(data[34:], 12)
+			return data
+		}(), true},
+		{"flac at 1 Hz", flacFile(samples, 1, 1), false},
+		{"ogg unknown codec", appendOggPage(nil, []byte("Speex   header"), 0, 0x02), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeAudio(tt.data)
+			if err == nil {
+				t.Fatal("DecodeAudio succeeded, want an error")
+			}
+			if errors.Is(err, ErrUnsupportedAudio) != tt.unsupported {
+				t.Errorf("error %q: unsupported = %v, want %v", err, !tt.unsupported, tt.unsupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package whisper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// decodeWAV decodes a RIFF/WAVE file with integer PCM (8, 16, 24 or 32 bit) or
+// IEEE float (32 or 64 bit) samples
+func decodeWAV(data []byte) (*decodedAudio, error) {
+	var (
+		format        uint16
+		channels      int
+		sampleRate    int
+		bitsPerSample int
+		pcm           []byte
+		haveFmt       bool
+	)
+
+	// Walk the chunks after the 12-byte RIFF header
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := data[offset+8:]
+		if size > len(body) {
+			// Streaming writers leave the size at 0 or 0xFFFFFFFF; use what is there
+			size = len(body)
+		}
+		body = body[:size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("invalid WAV fmt chunk")
+			}
+			format = binary.LittleEndian.Uint16(body[0:2])
+			channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			bitsPerSample = int(binary.LittleEndian.Uint16(body[14:16]))
+			if format == wavFormatExtensible && size >= 26 {
+				// The actual format is the first two bytes of the sub-format GUID
+				format = binary.LittleEndian.Uint16(body[24:26])
+			}
+			haveFmt = true
+		case "data":
+			pcm = body
+		}
+
+		// Chunks are padded to an even size
+		offset += 8 + size + size%2
+	}
+
+	if !haveFmt {
+		return nil, fmt.Errorf("invalid WAV file: missing fmt chunk")
+	}
+	if pcm == nil {
+		return nil, fmt.Errorf("invalid WAV file: missing data chunk")
+	}
+	if channels < 1 {
+		return nil, fmt.Errorf("invalid WAV file: %d channels", channels)
+	}
+
+	bytesPerSample := bitsPerSample / 8
+	if bytesPerSample == 0 {
+		return nil, fmt.Errorf("%w: %d-bit WAV", ErrUnsupportedAudio, bitsPerSample)
+	}
+	frameSize := bytesPerSample * channels
+	numSamples := len(pcm) / frameSize * channels
+	samples := make([]float32, numSamples)
+
+	switch {
+	case format == wavFormatPCM && bitsPerSample == 8:
+		for i := range samples {
+			samples[i] = (float32(pcm[i]) - 128) / 128
+		}
+	case format == wavFormatPCM && bitsPerSample == 16:
+		for i := range samples {
+			samples[i] = float32(int16(binary.LittleEndian.Uint16(pcm[i*2:]))) / 32768
+		}
+	case format == wavFormatPCM && bitsPerSample == 24:
+		for i := range samples {
+			b := pcm[i*3:]
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			samples[i] = float32(v) / 8388608
+		}
+	case format == wavFormatPCM && bitsPerSample == 32:
+		for i := range samples {
+			samples[i] = float32(int32(binary.LittleEndian.Uint32(pcm[i*4:]))) / 2147483648
+		}
+	case format == wavFormatFloat && bitsPerSample == 32:
+		for i := range samples {
+			samples[i] = math.Float32frombits(binary.LittleEndian.Uint32(pcm[i*4:]))
+		}
+	case format == wavFormatFloat && bitsPerSample == 64:
+		for i := range samples {
+			samples[i] = float32(math.Float64frombits(binary.LittleEndian.Uint64(pcm[i*8:])))
+		}
+	default:
+		return nil, fmt.Errorf("%w: WAV format %d with %d bits per sample", ErrUnsupportedAudio, format, bitsPerSample)
+	}
+
+	return &decodedAudio{samples: samples, channels: channels, sampleRate: sampleRate}, nil
+}
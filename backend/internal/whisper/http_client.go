@@ -3,7 +3,6 @@ package whisper
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -28,10 +27,29 @@ func NewHttpClient(baseURL string) *HttpClient {
 	}
 }
 
+// newManagedHttpClient creates a client for a whisper-server this process runs.
+// It has no overall timeout: transcribing long audio can take minutes, and
+// every request is bounded by its context instead.
+func newManagedHttpClient(baseURL string) *HttpClient {
+	return &HttpClient{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+	}
+}
+
 // Transcribe sends audio to whisper-server.exe via HTTP
 func (c *HttpClient) Transcribe(ctx context.Context, audioData []byte, language string) (string, error) {
+	result, err := c.TranscribeDetailed(ctx, audioData, language)
+	if err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
+
+// TranscribeDetailed sends audio to whisper-server and returns segments with timestamps
+func (c *HttpClient) TranscribeDetailed(ctx context.Context, audioData []byte, language string) (*Transcription, error) {
 	if len(audioData) == 0 {
-		return "", fmt.Errorf("audio data cannot be empty")
+		return nil, fmt.Errorf("audio data cannot be empty")
 	}
 
 	log.Printf("[HttpClient] Sending %d bytes of audio for transcription (language: %s)", len(audioData), language)
@@ -39,13 +57,24 @@ func (c *HttpClient) Transcribe(ctx context.Context, audioData []byte, language
 	// Convert audio bytes to float32 samples
 	samples, err := convertAudioToSamples(audioData)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert audio to samples: %w", err)
+		return nil, fmt.Errorf("failed to convert audio to samples: %w", err)
+	}
+
+	return c.TranscribeSamples(ctx, samples, 16000, language)
+}
+
+// TranscribeSamples sends mono float samples at any sample rate to whisper-server,
+// resampling them to the 16kHz the model expects
+func (c *HttpClient) TranscribeSamples(ctx context.Context, samples []float32, sampleRate int, language string) (*Transcription, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("audio data cannot be empty")
 	}
+	samples = Resample(samples, sampleRate, 16000)
 
 	// Create WAV file in memory
 	wavData, err := createWAV(samples)
 	if err != nil {
-		return "", fmt.Errorf("failed to create WAV: %w", err)
+		return nil, fmt.Errorf("failed to create WAV: %w", err)
 	}
 
 	// Create multipart form data
@@ -55,37 +84,37 @@ func (c *HttpClient) Transcribe(ctx context.Context, audioData []byte, language
 	// Add audio file
 	part, err := writer.CreateFormFile("file", "audio.wav")
 	if err != nil {
-		return "", fmt.Errorf("failed to create form file: %w", err)
+		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
 	_, err = part.Write(wavData)
 	if err != nil {
-		return "", fmt.Errorf("failed to write audio data: %w", err)
+		return nil, fmt.Errorf("failed to write audio data: %w", err)
 	}
 
-	// Add language parameter if specified
-	if language != "" && language != "auto" {
+	// Add language parameter if specified ("auto" asks the server to detect it)
+	if language != "" {
 		err = writer.WriteField("language", language)
 		if err != nil {
-			return "", fmt.Errorf("failed to write language field: %w", err)
+			return nil, fmt.Errorf("failed to write language field: %w", err)
 		}
 	}
 
-	// Add response format
-	err = writer.WriteField("response_format", "json")
+	// verbose_json includes segment and word timestamps
+	err = writer.WriteField("response_format", "verbose_json")
 	if err != nil {
-		return "", fmt.Errorf("failed to write response format: %w", err)
+		return nil, fmt.Errorf("failed to write response format: %w", err)
 	}
 
 	err = writer.Close()
 	if err != nil {
-		return "", fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/inference", body)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
@@ -94,34 +123,33 @@ func (c *HttpClient) Transcribe(ctx context.Context, audioData []byte, language
 	startTime := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	// Parse JSON response
-	var result struct {
-		Text     string `json:"text"`
-		Language string `json:"language"`
-		Segments []struct {
-			Text string `json:"text"`
-		} `json:"segments"`
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	result, err := parseServerJSON(bodyBytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
+	}
+	if result.Duration == 0 {
+		result.Duration = float64(len(samples)) / 16000
 	}
 
 	duration := time.Since(startTime)
 	log.Printf("[HttpClient] Transcription completed in %dms: %s", duration.Milliseconds(), result.Text)
 
-	return result.Text, nil
+	return result, nil
 }
 
 // IsConnected checks if the HTTP server is reachable
@@ -0,0 +1,228 @@
+package whisper
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/downloader"
+)
+
+var (
+	// ErrUnknownModel is returned for a model name that is not in the catalog
+	ErrUnknownModel = errors.New("unknown whisper model")
+
+	// ErrModelNotInstalled is returned when a model has not been downloaded yet
+	ErrModelNotInstalled = errors.New("whisper model is not installed")
+)
+
+// ModelInfo describes a Whisper model and whether it can be used
+type ModelInfo struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	EnglishOnly  bool   `json:"english_only"`
+	Quantization string `json:"quantization,omitempty"` // e.g. q5_1; empty for full precision
+	Installed    bool   `json:"installed"`
+	Active       bool   `json:"active"`
+}
+
+// resolveModel picks the model to start with: a model file given as
+// ModelPath, the catalog model named by Model, or the catalog default
+func (s *STTService) resolveModel() {
+	if isModelFile(s.config.ModelPath) {
+		s.model = modelName(s.config.ModelPath)
+		s.modelPath = s.config.ModelPath
+		return
+	}
+
+	name := s.config.Model
+	if _, ok := catalog.Default().Model("stt", name); !ok {
+		if name != "" {
+			log.Printf("Warning: Unknown whisper model %q, using the default model", name)
+		}
+		name = ""
+		if model, ok := catalog.Default().DefaultModel("stt"); ok {
+			name = model.Name
+		}
+	}
+	s.model = name
+	s.modelPath, _ = s.catalogModelPath(name)
+}
+
+// modelsDir returns the directory Whisper models are downloaded to
+func (s *STTService) modelsDir() string {
+	switch {
+	case s.config.ModelPath == "":
+		return filepath.Dir(s.assetManager.GetModelPath("whisper"))
+	case isModelFile(s.config.ModelPath):
+		return filepath.Dir(s.config.ModelPath)
+	}
+	return s.config.ModelPath
+}
+
+// catalogModelPath returns where a catalog model is stored
+func (s *STTService) catalogModelPath(name string) (string, bool) {
+	model, ok := catalog.Default().Model("stt", name)
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(s.modelsDir(), model.Files[0].File), true
+}
+
+// installedModelPath returns the path of a model that is ready to use
+func (s *STTService) installedModelPath(name string) (string, error) {
+	s.mu.RLock()
+	active, activePath := s.model, s.modelPath
+	s.mu.RUnlock()
+
+	path, ok := s.catalogModelPath(name)
+	if name == active {
+		path, ok = activePath, true
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownModel, name)
+	}
+	if !s.assetManager.IsAssetAvailable(path) {
+		return "", fmt.Errorf("%w: %s", ErrModelNotInstalled, name)
+	}
+	return path, nil
+}
+
+// ActiveModel returns the name of the model used for transcription
+func (s *STTService) ActiveModel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model
+}
+
+// ListModels returns the Whisper models in the catalog and whether they are
+// installed. A model file configured outside the catalog is listed as well.
+func (s *STTService) ListModels() []ModelInfo {
+	s.mu.RLock()
+	active, activePath := s.model, s.modelPath
+	s.mu.RUnlock()
+
+	var models []ModelInfo
+	listedActive := false
+	for _, model := range catalog.Default().ServiceModels("stt") {
+		path, _ := s.catalogModelPath(model.Name)
+		info := newModelInfo(model.Name)
+		info.Description = model.Description
+		info.Active = model.Name == active
+		if info.Active {
+			path = activePath
+		}
+		info.Installed = s.assetManager.IsAssetAvailable(path)
+		listedActive = listedActive || info.Active
+		models = append(models, info)
+	}
+
+	if !listedActive && active != "" {
+		info := newModelInfo(active)
+		info.Installed = s.assetManager.IsAssetAvailable(activePath)
+		info.Active = true
+		models = append(models, info)
+	}
+	return models
+}
+
+// ModelFiles returns the files that make up the active Whisper model
+func (s *STTService) ModelFiles() []downloader.File {
+	s.mu.RLock()
+	name, path := s.model, s.modelPath
+	s.mu.RUnlock()
+
+	files, err := s.ModelFilesFor(name)
+	if err != nil || files[0].DestPath != path {
+		// A model file configured outside the catalog can't be downloaded
+		return []downloader.File{{DestPath: path}}
+	}
+	return files
+}
+
+// ModelFilesFor returns the files to download for a catalog model
+func (s *STTService) ModelFilesFor(name string) ([]downloader.File, error) {
+	model, ok := catalog.Default().Model("stt", name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModel, name)
+	}
+
+	modelsDir := s.modelsDir()
+	files := make([]downloader.File, 0, len(model.Files))
+	for _, artifact := range model.Files {
+		files = append(files, artifact.Download(modelsDir))
+	}
+	return files, nil
+}
+
+// SetModel switches transcription to another installed model. The managed
+// whisper-server is restarted with it; an external HTTP or gRPC server keeps
+// its own model, and the selection only applies to the CLI fallback.
+// Selecting the active model again is a no-op.
+func (s *STTService) SetModel(name string) error {
+	path, err := s.installedModelPath(name)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == s.model && path == s.modelPath {
+		return nil
+	}
+
+	s.model = name
+	s.modelPath = path
+	s.loadModel()
+
+	log.Printf("Whisper model switched to %s", name)
+	return nil
+}
+
+// loadModel (re)starts the managed whisper-server with the active model;
+// called with s.mu held
+func (s *STTService) loadModel() {
+	// The CLI resolves the model path on every transcription; the managed
+	// server has the old model loaded and is restarted with the new one
+	if s.server != nil {
+		s.server.stop()
+		s.server = nil
+	}
+	if !s.config.DisableServer && !s.useHTTP && !s.useGRPC {
+		go s.startServer(s.modelPath)
+	}
+	s.info.Model = s.model
+	s.info.Metadata["model_path"] = s.modelPath
+	s.info.LastUpdated = time.Now()
+}
+
+func newModelInfo(name string) ModelInfo {
+	info := ModelInfo{Name: name}
+	base := name
+	if i := strings.LastIndex(name, "-q"); i > 0 {
+		base, info.Quantization = name[:i], name[i+1:]
+	}
+	info.EnglishOnly = strings.HasSuffix(base, ".en")
+	return info
+}
+
+// isModelFile reports whether a configured model path names a single ggml
+// file rather than a directory of models
+func isModelFile(path string) bool {
+	return strings.HasSuffix(path, ".bin")
+}
+
+// modelName derives a model name from a file name, e.g. ggml-small.en.bin
+// becomes small.en
+func modelName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".bin")
+	for _, prefix := range []string{"ggml-", "whisper-"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+package whisper
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNewModelInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		englishOnly  bool
+		quantization string
+	}{
+		{"tiny", false, ""},
+		{"base.en", true, ""},
+		{"small.en-q5_1", true, "q5_1"},
+		{"medium-q5_0", false, "q5_0"},
+		{"large-v3", false, ""},
+		{"large-v3-turbo-q8_0", false, "q8_0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := newModelInfo(tt.name)
+			if info.Name != tt.name || info.EnglishOnly != tt.englishOnly || info.Quantization != tt.quantization {
+				t.Errorf("got %+v, want english_only %v and quantization %q", info, tt.englishOnly, tt.quantization)
+			}
+		})
+	}
+}
+
+func TestModelName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"ggml-small.en.bin", "small.en"},
+		{"/models/whisper-large-v3-q5_0.bin", "large-v3-q5_0"},
+		{filepath.Join("models", "ggml-base.bin"), "base"},
+		{"custom.bin", "custom"},
+	}
+
+	for _, tt := range tests {
+		if got := modelName(tt.path); got != tt.want {
+			t.Errorf("modelName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolveModel(t *testing.T) {
+	dir := t.TempDir()
+	modelFile := filepath.Join(dir, "ggml-small.en.bin")
+
+	tests := []struct {
+		name      string
+		modelPath string
+		model     string
+		wantModel string
+		wantPath  string
+	}{
+		{"model file", modelFile, "large-v3", "small.en", modelFile},
+		{"catalog model", dir, "small.en-q5_1", "small.en-q5_1", filepath.Join(dir, "whisper-small.en-q5_1.bin")},
+		{"unknown model", dir, "huge", "base", filepath.Join(dir, "whisper-base.bin")},
+		{"default model", dir, "", "base", filepath.Join(dir, "whisper-base.bin")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &STTService{config: &Config{ModelPath: tt.modelPath, Model: tt.model}}
+			s.resolveModel()
+			if s.model != tt.wantModel || s.modelPath != tt.wantPath {
+				t.Errorf("resolved %q at %s, want %q at %s", s.model, s.modelPath, tt.wantModel, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package whisper
+
+import (
+	"math"
+)
+
+const (
+	// resampleZeroCrossings is the number of sinc zero crossings on each side of
+	// the filter center; more gives a sharper cutoff at higher cost
+	resampleZeroCrossings = 16
+
+	// resampleRolloff places the cutoff slightly below Nyquist to leave room for
+	// the transition band
+	resampleRolloff = 0.94
+
+	// resampleKaiserBeta sets the stopband attenuation of the window (~85 dB)
+	resampleKaiserBeta = 8.6
+
+	// resampleMaxPhases caps the filter table size for awkward rate ratios
+	resampleMaxPhases = 1024
+)
+
+// Resample converts mono samples from one sample rate to another using a
+// polyphase Kaiser-windowed sinc filter, which also low-pass filters the
+// signal when downsampling so no aliasing is introduced
+func Resample(samples []float32, fromRate, toRate int) []float32 {
+	if fromRate == toRate || fromRate <= 0 || toRate <= 0 || len(samples) == 0 {
+		return samples
+	}
+
+	rs := newResampler(fromRate, toRate)
+	out := make([]float32, int(int64(len(samples))*int64(toRate)/int64(fromRate)))
+	for i := range out {
+		out[i] = rs.at(samples, 0, int64(i))
+	}
+	return out
+}
+
+// resampler holds the polyphase filter for one rate conversion
+type resampler struct {
+	up, step  int // Output sample i sits at input position i*step/up
+	phases    int
+	halfWidth int
+	filter    []float64
+}
+
+func newResampler(fromRate, toRate int) *resampler {
+	g := gcd(fromRate, toRate)
+	rs := &resampler{up: toRate / g, step: fromRate / g}
+
+	rs.phases = min(rs.up, resampleMaxPhases)
+	cutoff := resampleRolloff * math.Min(1, float64(toRate)/float64(fromRate))
+	rs.halfWidth = int(math.Ceil(resampleZeroCrossings / cutoff))
+	rs.filter = resampleFilter(rs.phases, rs.halfWidth, cutoff)
+	return rs
+}
+
+// at computes output sample i. input holds the input samples starting at
+// index offset; samples outside it are treated as silence.
+func (rs *resampler) at(input []float32, offset int64, i int64) float32 {
+	taps := 2 * rs.halfWidth
+	pos := i * int64(rs.step)
+	base := pos / int64(rs.up)
+	phase := int((pos % int64(rs.up)) * int64(rs.phases) / int64(rs.up))
+	coeffs := rs.filter[phase*taps : (phase+1)*taps]
+
+	var sum float64
+	start := base - int64(rs.halfWidth) + 1 - offset
+	for k, c := range coeffs {
+		idx := start + int64(k)
+		if idx >= 0 && idx < int64(len(input)) {
+			sum += float64(input[idx]) * c
+		}
+	}
+	return float32(sum)
+}
+
+// StreamResampler resamples audio that arrives in chunks, keeping enough
+// history between chunks that the output matches resampling it in one piece
+type StreamResampler struct {
+	rs       *resampler
+	buf      []float32
+	bufStart int64 // Input index of buf[0]
+	next     int64 // Next output sample to produce
+}
+
+// NewStreamResampler creates a resampler for chunked input; when the rates
+// already match it passes input through unchanged
+func NewStreamResampler(fromRate, toRate int) *StreamResampler {
+	if fromRate == toRate || fromRate <= 0 || toRate <= 0 {
+		return &StreamResampler{}
+	}
+	return &StreamResampler{rs: newResampler(fromRate, toRate)}
+}
+
+// Process consumes a chunk of input and returns the output samples that can be
+// computed so far. Output lags the input by the filter's half width.
+func (r *StreamResampler) Process(samples []float32) []float32 {
+	if r.rs == nil {
+		return samples
+	}
+
+	r.buf = append(r.buf, samples...)
+	end := r.bufStart + int64(len(r.buf))
+
+	var out []float32
+	for {
+		base := r.next * int64(r.rs.step) / int64(r.rs.up)
+		if base+int64(r.rs.halfWidth) >= end {
+			break
+		}
+		out = append(out, r.rs.at(r.buf, r.bufStart, r.next))
+		r.next++
+	}
+
+	// Keep only the history the next output sample still needs
+	base := r.next * int64(r.rs.step) / int64(r.rs.up)
+	if drop := base - int64(r.rs.halfWidth) + 1 - r.bufStart; drop > 0 {
+		r.buf = append(r.buf[:0], r.buf[drop:]...)
+		r.bufStart += drop
+	}
+	return out
+}
+
+// resampleFilter builds the filter table: for each fractional phase, the taps
+// applied to input samples base-halfWidth+1 .. base+halfWidth
+func resampleFilter(phases, halfWidth int, cutoff float64) []float64 {
+	taps := 2 * halfWidth
+	filter := make([]float64, phases*taps)
+	norm := besselI0(resampleKaiserBeta)
+
+	for p := 0; p < phases; p++ {
+		frac := float64(p) / float64(phases)
+		row := filter[p*taps : (p+1)*taps]
+
+		var sum float64
+		for k := range row {
+			// Distance from the output position to input sample base-halfWidth+1+k
+			t := frac + float64(halfWidth-1-k)
+			x := t / float64(halfWidth)
+			if x <= -1 || x >= 1 {
+				continue
+			}
+			window := besselI0(resampleKaiserBeta*math.Sqrt(1-x*x)) / norm
+			row[k] = cutoff * sinc(cutoff*t) * window
+			sum += row[k]
+		}
+
+		// Normalize each phase to unity gain at DC
+		if sum != 0 {
+			for k := range row {
+				row[k] /= sum
+			}
+		}
+	}
+	return filter
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// besselI0 evaluates the zeroth-order modified Bessel function of the first kind
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	for k := 1; k < 50; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+		if term < sum*1e-12 {
+			break
+		}
+	}
+	return sum
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ResamplePCM16 converts little-endian 16-bit PCM audio to 16kHz
+func ResamplePCM16(audioData []byte, sampleRate int) ([]byte, error) {
+	if sampleRate == 0 || sampleRate == 16000 {
+		return audioData, nil
+	}
+
+	samples, err := convertAudioToSamples(audioData)
+	if err != nil {
+		return nil, err
+	}
+	return EncodePCM16(Resample(samples, sampleRate, 16000)), nil
+}
@@ -0,0 +1,205 @@
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// serverStartTimeout bounds how long whisper-server may take to load its model
+	serverStartTimeout = 2 * time.Minute
+
+	// serverHealthInterval is how often a running server is health-checked
+	serverHealthInterval = 10 * time.Second
+
+	// serverMaxHealthFailures is how many failed checks in a row trigger a restart
+	serverMaxHealthFailures = 3
+
+	// serverMaxBackoff caps the delay between restart attempts
+	serverMaxBackoff = 30 * time.Second
+)
+
+// managedServer runs a whisper.cpp server process with the model loaded once,
+// restarting it when it crashes or stops answering health checks
+type managedServer struct {
+	binary    string
+	modelPath string
+	language  string
+	useGPU    bool
+
+	mu     sync.RWMutex
+	client *HttpClient // nil while the server is starting or restarting
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startManagedServer launches and supervises a whisper-server process
+func startManagedServer(binary, modelPath, language string, useGPU bool) *managedServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &managedServer{
+		binary:    binary,
+		modelPath: modelPath,
+		language:  language,
+		useGPU:    useGPU,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go m.supervise(ctx)
+	return m
+}
+
+// Client returns a client for the server, or nil if it is not currently healthy
+func (m *managedServer) Client() *HttpClient {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.client
+}
+
+// stop terminates the server and waits for the supervisor to exit
+func (m *managedServer) stop() {
+	m.cancel()
+	<-m.done
+}
+
+// supervise keeps the server running until ctx is cancelled
+func (m *managedServer) supervise(ctx context.Context) {
+	defer close(m.done)
+
+	backoff := time.Second
+	for {
+		startedAt := time.Now()
+		if err := m.run(ctx); err != nil {
+			log.Printf("[WhisperServer] %v", err)
+		}
+		m.setClient(nil)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A server that ran for a while gets restarted promptly again
+		if time.Since(startedAt) > time.Minute {
+			backoff = time.Second
+		}
+		log.Printf("[WhisperServer] Restarting in %v", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, serverMaxBackoff)
+	}
+}
+
+// run starts one server process and returns once it exits or becomes unhealthy
+func (m *managedServer) run(ctx context.Context) error {
+	port, err := freePort()
+	if err != nil {
+		return fmt.Errorf("failed to find a free port: %w", err)
+	}
+
+	args := []string{
+		"-m", m.modelPath,
+		"--host", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+	}
+	if m.language != "" {
+		args = append(args, "-l", m.language)
+	}
+	if !m.useGPU {
+		args = append(args, "-ng")
+	}
+
+	cmd := exec.Command(m.binary, args...)
+	cmd.Env = whisperEnv(m.binary)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = io.Discard
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start whisper-server: %w", err)
+	}
+	log.Printf("[WhisperServer] Started %s (pid %d) on port %d", m.binary, cmd.Process.Pid, port)
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	defer func() {
+		cmd.Process.Kill()
+		<-exited
+	}()
+
+	client := newManagedHttpClient(fmt.Sprintf("http://127.0.0.1:%d", port))
+
+	// Wait for the model to load
+	startCtx, cancelStart := context.WithTimeout(ctx, serverStartTimeout)
+	defer cancelStart()
+	for !m.healthy(startCtx, client) {
+		select {
+		case <-startCtx.Done():
+			return fmt.Errorf("whisper-server did not become healthy: %w", startCtx.Err())
+		case err := <-exited:
+			exited <- err
+			return fmt.Errorf("whisper-server exited during startup: %v", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	log.Printf("[WhisperServer] Ready on port %d", port)
+	m.setClient(client)
+
+	ticker := time.NewTicker(serverHealthInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-exited:
+			exited <- err
+			return fmt.Errorf("whisper-server exited: %v", err)
+		case <-ticker.C:
+			if m.healthy(ctx, client) {
+				failures = 0
+				continue
+			}
+			failures++
+			if failures >= serverMaxHealthFailures {
+				return fmt.Errorf("whisper-server failed %d health checks", failures)
+			}
+		}
+	}
+}
+
+func (m *managedServer) healthy(ctx context.Context, client *HttpClient) bool {
+	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	ok, err := client.HealthCheck(checkCtx)
+	return err == nil && ok
+}
+
+func (m *managedServer) setClient(client *HttpClient) {
+	m.mu.Lock()
+	m.client = client
+	m.mu.Unlock()
+}
+
+// freePort asks the OS for an unused local TCP port
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
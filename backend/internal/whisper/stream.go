@@ -0,0 +1,280 @@
+package whisper
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// streamFrameSamples is the VAD analysis frame (30ms at 16kHz)
+	streamFrameSamples = 480
+
+	// streamSpeechFrames is how many voiced frames in a row start an utterance
+	streamSpeechFrames = 3
+
+	// streamSilenceFrames is how much trailing silence ends an utterance (~700ms)
+	streamSilenceFrames = 23
+
+	// streamPrerollFrames keeps audio from just before speech was detected (~300ms)
+	streamPrerollFrames = 10
+
+	// streamPartialInterval is how much new speech triggers a partial transcript
+	streamPartialInterval = 16000
+
+	// streamMaxUtterance forces a final transcript for long monologues (30s)
+	streamMaxUtterance = 30 * 16000
+)
+
+// StreamEvent types
+const (
+	StreamEventSpeechStart = "speech_start"
+	StreamEventPartial     = "partial"
+	StreamEventFinal       = "final"
+	StreamEventError       = "error"
+)
+
+// StreamEvent is emitted by a streaming transcription session
+type StreamEvent struct {
+	Type  string  `json:"type"`
+	Text  string  `json:"text,omitempty"`
+	Start float64 `json:"start"` // Seconds since the stream started
+	End   float64 `json:"end,omitempty"`
+	Error string  `json:"error,omitempty"`
+
+	// Result holds the full transcription of a final event
+	Result *Transcription `json:"result,omitempty"`
+}
+
+// streamJob is an utterance (or the beginning of one) waiting for transcription
+type streamJob struct {
+	samples []float32
+	start   float64
+	final   bool
+}
+
+// StreamSession transcribes continuous 16kHz mono audio, detecting where
+// utterances start and end and emitting partial and final transcripts
+type StreamSession struct {
+	service   *STTService
+	language  string
+	threshold float64
+	onEvent   func(StreamEvent)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   chan streamJob
+	done   chan struct{}
+
+	mu             sync.Mutex
+	pending        []float32   // Samples not yet filling a whole frame
+	preroll        [][]float32 // Recent frames before speech started
+	utterance      []float32
+	inSpeech       bool
+	voicedRun      int
+	silentRun      int
+	utteranceStart float64
+	lastPartial    int
+	processed      int64 // Samples consumed since the stream started
+	closed         bool
+
+	// partialQueued is set while a partial transcript is waiting, so slow
+	// transcription skips partials instead of falling behind
+	partialQueued atomic.Bool
+}
+
+// NewStream starts a streaming session. onEvent is called both from Write and
+// from a background goroutine, so it must be safe for concurrent use.
+func (s *STTService) NewStream(ctx context.Context, language string, onEvent func(StreamEvent)) *StreamSession {
+	ctx, cancel := context.WithCancel(ctx)
+	ss := &StreamSession{
+		service:   s,
+		language:  language,
+		threshold: s.config.VoiceThreshold,
+		onEvent:   onEvent,
+		ctx:       ctx,
+		cancel:    cancel,
+		jobs:      make(chan streamJob, 16),
+		done:      make(chan struct{}),
+	}
+	go ss.transcribeJobs()
+	return ss
+}
+
+// Write feeds 16kHz mono samples into the session
+func (ss *StreamSession) Write(samples []float32) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.closed {
+		return
+	}
+
+	ss.pending = append(ss.pending, samples...)
+	for len(ss.pending) >= streamFrameSamples {
+		frame := make([]float32, streamFrameSamples)
+		copy(frame, ss.pending)
+		ss.pending = ss.pending[streamFrameSamples:]
+		ss.processFrame(frame)
+	}
+}
+
+// Skip advances the stream clock over samples that were not written, e.g.
+// while waiting for a wake word, so event times stay relative to the start of
+// the stream. Any utterance in progress is ended first.
+func (ss *StreamSession) Skip(samples int) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.closed {
+		return
+	}
+	if ss.inSpeech {
+		ss.endUtterance()
+	}
+	ss.processed += int64(len(ss.pending) + samples)
+	ss.pending = nil
+	ss.preroll = nil
+	ss.voicedRun = 0
+}
+
+// Close flushes any utterance in progress, waits for its final transcript and
+// ends the session
+func (ss *StreamSession) Close() {
+	ss.mu.Lock()
+	if ss.closed {
+		ss.mu.Unlock()
+		return
+	}
+	if ss.inSpeech {
+		ss.endUtterance()
+	}
+	ss.closed = true
+	ss.mu.Unlock()
+
+	close(ss.jobs)
+	<-ss.done
+	ss.cancel()
+}
+
+// Abort ends the session without waiting for outstanding transcriptions
+func (ss *StreamSession) Abort() {
+	ss.cancel()
+	ss.Close()
+}
+
+// processFrame runs endpointing on one frame; called with ss.mu held
+func (ss *StreamSession) processFrame(frame []float32) {
+	voiced := isSpeechFrame(frame, ss.threshold)
+	frameStart := float64(ss.processed) / 16000
+	ss.processed += int64(len(frame))
+
+	if !ss.inSpeech {
+		ss.preroll = append(ss.preroll, frame)
+		if len(ss.preroll) > streamPrerollFrames {
+			ss.preroll = ss.preroll[1:]
+		}
+		if !voiced {
+			ss.voicedRun = 0
+			return
+		}
+		ss.voicedRun++
+		if ss.voicedRun < streamSpeechFrames {
+			return
+		}
+
+		// Speech started; include the pre-roll so the first syllable isn't clipped
+		ss.inSpeech = true
+		ss.silentRun = 0
+		ss.lastPartial = 0
+		ss.utterance = ss.utterance[:0]
+		for _, f := range ss.preroll {
+			ss.utterance = append(ss.utterance, f...)
+		}
+		ss.utteranceStart = frameStart + float64(streamFrameSamples)/16000 - float64(len(ss.utterance))/16000
+		ss.preroll = nil
+		ss.emit(StreamEvent{Type: StreamEventSpeechStart, Start: ss.utteranceStart})
+		return
+	}
+
+	ss.utterance = append(ss.utterance, frame...)
+	if voiced {
+		ss.silentRun = 0
+	} else {
+		ss.silentRun++
+	}
+
+	switch {
+	case ss.silentRun >= streamSilenceFrames || len(ss.utterance) >= streamMaxUtterance:
+		ss.endUtterance()
+	case len(ss.utterance)-ss.lastPartial >= streamPartialInterval && !ss.partialQueued.Load():
+		ss.lastPartial = len(ss.utterance)
+		ss.partialQueued.Store(true)
+		ss.queue(streamJob{samples: append([]float32(nil), ss.utterance...), start: ss.utteranceStart})
+	}
+}
+
+// endUtterance queues the current utterance for its final transcript; called with ss.mu held
+func (ss *StreamSession) endUtterance() {
+	samples := ss.utterance
+	// Drop most of the trailing silence that ended the utterance
+	if trim := (ss.silentRun - streamPrerollFrames) * streamFrameSamples; trim > 0 && trim < len(samples) {
+		samples = samples[:len(samples)-trim]
+	}
+
+	ss.queue(streamJob{samples: samples, start: ss.utteranceStart, final: true})
+	ss.utterance = nil
+	ss.inSpeech = false
+	ss.voicedRun = 0
+	ss.silentRun = 0
+}
+
+func (ss *StreamSession) queue(job streamJob) {
+	select {
+	case ss.jobs <- job:
+	case <-ss.ctx.Done():
+	}
+}
+
+func (ss *StreamSession) emit(event StreamEvent) {
+	if ss.ctx.Err() == nil {
+		ss.onEvent(event)
+	}
+}
+
+// transcribeJobs runs queued transcriptions one at a time, in order
+func (ss *StreamSession) transcribeJobs() {
+	defer close(ss.done)
+
+	for job := range ss.jobs {
+		if !job.final {
+			ss.partialQueued.Store(false)
+		}
+		if ss.ctx.Err() != nil {
+			continue
+		}
+
+		result, err := ss.service.TranscribeDetailed(ss.ctx, EncodePCM16(job.samples), ss.language)
+		if err != nil {
+			if ss.ctx.Err() == nil {
+				log.Printf("[STTStream] Transcription failed: %v", err)
+				ss.emit(StreamEvent{Type: StreamEventError, Start: job.start, Error: err.Error()})
+			}
+			continue
+		}
+
+		event := StreamEvent{
+			Type:  StreamEventPartial,
+			Text:  result.Text,
+			Start: job.start,
+			End:   job.start + float64(len(job.samples))/16000,
+		}
+		if job.final {
+			event.Type = StreamEventFinal
+			result.Duration = float64(len(job.samples)) / 16000
+			event.Result = result
+		}
+		ss.emit(event)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,12 +14,15 @@ import (
 	"time"
 	"archive/zip"
 
+	"alice-backend/internal/catalog"
+	"alice-backend/internal/downloader"
 	"alice-backend/internal/embedded"
 )
 
 // WhisperGRPCClient interface for dependency injection
 type WhisperGRPCClient interface {
 	Transcribe(ctx context.Context, audioData []byte, language string) (string, error)
+	TranscribeDetailed(ctx context.Context, audioData []byte, language string) (*Transcription, error)
 	IsConnected() bool
 	HealthCheck(ctx context.Context) (bool, error)
 }
@@ -29,9 +31,22 @@ type WhisperGRPCClient interface {
 // Config holds STT configuration
 type Config struct {
 	Language       string
-	ModelPath      string
 	SampleRate     int
 	VoiceThreshold float64
+
+	// ModelPath is a directory of ggml models or a single model file; empty
+	// uses the app's models directory
+	ModelPath string
+
+	// Model selects a catalog model such as small.en or large-v3-q5_0; empty
+	// uses the catalog default
+	Model string
+
+	// DisableServer turns off the managed whisper-server and runs the CLI per request
+	DisableServer bool
+
+	// DisableVAD sends audio to whisper untrimmed, even when it is silent
+	DisableVAD bool
 }
 
 // ServiceInfo contains information about the STT service
@@ -56,6 +71,13 @@ type STTService struct {
 	useGRPC      bool
 	httpClient   *HttpClient
 	useHTTP      bool
+	downloader   *downloader.Downloader
+	server       *managedServer // whisper-server supervised by this service
+	model        string         // Name of the active model
+	modelPath    string         // File of the active model
+
+	capsMu sync.Mutex
+	caps   *binaryCapabilities // probed on first use
 }
 
 // NewSTTService creates a new STT service
@@ -71,19 +93,23 @@ func NewSTTService(config *Config) *STTService {
 	baseDir := embedded.GetProductionBaseDirectory()
 	assetManager := embedded.NewAssetManager(baseDir)
 
-	return &STTService{
+	s := &STTService{
 		config:       config,
 		assetManager: assetManager,
+		downloader:   downloader.NewDownloader(log.Default()),
 		info: &ServiceInfo{
 			Name:        "Whisper STT",
 			Version:     "1.0.0",
 			Status:      "initializing",
-			Model:       "whisper",
 			Language:    config.Language,
 			LastUpdated: time.Now(),
 			Metadata:    make(map[string]string),
 		},
 	}
+	s.resolveModel()
+	s.info.Model = s.model
+
+	return s
 }
 
 // Initialize initializes the STT service
@@ -101,16 +127,12 @@ func (s *STTService) Initialize(ctx context.Context) error {
 		log.Println("Successfully extracted embedded Whisper assets")
 	}
 
-	if s.config.ModelPath == "" {
-		s.config.ModelPath = "models/whisper-base.bin"
-	}
-
 	// Ensure Whisper model is available
-	modelPath := s.assetManager.GetModelPath("whisper")
+	modelPath := s.modelPath
 	if !s.assetManager.IsAssetAvailable(modelPath) {
 		log.Printf("Whisper model not found at %s, will download when needed", modelPath)
 		// Download model during initialization to avoid delays during transcription
-		if err := s.downloadWhisperModel(ctx, modelPath); err != nil {
+		if err := s.downloadWhisperModel(ctx, s.model); err != nil {
 			log.Printf("Warning: Failed to download Whisper model during initialization: %v", err)
 			log.Println("Will attempt to download when transcription is requested")
 		} else {
@@ -118,11 +140,17 @@ func (s *STTService) Initialize(ctx context.Context) error {
 		}
 	}
 
+	// Keep the model loaded in a whisper-server unless a remote backend is configured
+	if !s.config.DisableServer && !s.useHTTP && !s.useGRPC {
+		go s.startServer(modelPath)
+	}
+
 	s.ready = true
 	s.info.Status = "ready"
+	s.info.Metadata["model_path"] = modelPath
 	s.info.LastUpdated = time.Now()
 
-	log.Println("Whisper STT service initialized successfully")
+	log.Printf("Whisper STT service initialized successfully (model: %s)", s.model)
 	return nil
 }
 
@@ -143,6 +171,11 @@ func (s *STTService) GetInfo() *ServiceInfo {
 	return &info
 }
 
+// VoiceThreshold returns the RMS level above which audio counts as speech
+func (s *STTService) VoiceThreshold() float64 {
+	return s.config.VoiceThreshold
+}
+
 // SetGRPCClient sets the gRPC client for remote transcription
 func (s *STTService) SetGRPCClient(client WhisperGRPCClient) {
 	s.mu.Lock()
@@ -184,12 +217,80 @@ func (s *STTService) TranscribeAudio(ctx context.Context, audioData []byte) (str
 
 // TranscribeAudioWithLanguage performs speech transcription with optional language override
 func (s *STTService) TranscribeAudioWithLanguage(ctx context.Context, audioData []byte, language string) (string, error) {
+	result, err := s.TranscribeDetailed(ctx, audioData, language)
+	if err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
+
+// TranscribeDetailed transcribes audio and returns segments, word timestamps,
+// confidence and the detected language. Leading and trailing silence is
+// trimmed first, and audio without speech is not sent to whisper at all.
+func (s *STTService) TranscribeDetailed(ctx context.Context, audioData []byte, language string) (*Transcription, error) {
+	return s.TranscribeDetailedWithModel(ctx, audioData, language, "")
+}
+
+// TranscribeDetailedWithModel is TranscribeDetailed with an installed model
+// other than the active one. The managed whisper-server only has the active
+// model loaded, so other models are run with the CLI.
+func (s *STTService) TranscribeDetailedWithModel(ctx context.Context, audioData []byte, language, model string) (*Transcription, error) {
 	if !s.IsReady() {
-		return "", fmt.Errorf("Whisper STT service is not ready")
+		return nil, fmt.Errorf("Whisper STT service is not ready")
 	}
 
 	if len(audioData) == 0 {
-		return "", fmt.Errorf("audio data cannot be empty")
+		return nil, fmt.Errorf("audio data cannot be empty")
+	}
+
+	// An empty model path means the active model
+	var modelPath string
+	if model != "" && model != s.ActiveModel() {
+		path, err := s.installedModelPath(model)
+		if err != nil {
+			return nil, err
+		}
+		modelPath = path
+	}
+
+	if s.config.DisableVAD {
+		return s.transcribe(ctx, audioData, language, modelPath)
+	}
+
+	samples, err := convertAudioToSamples(audioData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert audio: %w", err)
+	}
+	duration := float64(len(samples)) / 16000
+
+	start, end, ok := speechBounds(samples, s.config.VoiceThreshold)
+	if !ok {
+		log.Printf("[STT] No speech detected in %.1fs of audio, skipping transcription", duration)
+		return &Transcription{Duration: duration}, nil
+	}
+
+	result, err := s.transcribe(ctx, audioData[start*2:end*2], language, modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report timestamps relative to the untrimmed audio
+	result.shift(float64(start) / 16000)
+	result.Duration = duration
+	return result, nil
+}
+
+// transcribe sends audio to the first available backend: an external HTTP or
+// gRPC server, the managed whisper-server, or the CLI. A model path other
+// than the active model's always uses the CLI.
+func (s *STTService) transcribe(ctx context.Context, audioData []byte, language, modelPath string) (*Transcription, error) {
+	if modelPath != "" {
+		samples, err := s.convertAudioToSamples(audioData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert audio: %w", err)
+		}
+		log.Printf("[STT] Using CLI mode for transcription with %s", filepath.Base(modelPath))
+		return s.transcribeDirectlyWithLanguage(ctx, samples, language, modelPath)
 	}
 
 	// Try HTTP first if enabled and connected (preferred over gRPC)
@@ -199,10 +300,10 @@ func (s *STTService) TranscribeAudioWithLanguage(ctx context.Context, audioData
 
 	if useHTTP {
 		log.Println("[STT] Using HTTP mode for transcription")
-		text, err := s.httpClient.Transcribe(ctx, audioData, language)
+		result, err := s.httpClient.TranscribeDetailed(ctx, audioData, language)
 		if err == nil {
-			log.Printf("[STT] HTTP transcription successful: %s", text)
-			return text, nil
+			log.Printf("[STT] HTTP transcription successful: %s", result.Text)
+			return result, nil
 		}
 
 		// Log HTTP failure and fall back to CLI
@@ -220,10 +321,10 @@ func (s *STTService) TranscribeAudioWithLanguage(ctx context.Context, audioData
 
 	if useGRPC {
 		log.Println("[STT] Using gRPC mode for transcription")
-		text, err := s.grpcClient.Transcribe(ctx, audioData, language)
+		result, err := s.grpcClient.TranscribeDetailed(ctx, audioData, language)
 		if err == nil {
-			log.Printf("[STT] gRPC transcription successful: %s", text)
-			return text, nil
+			log.Printf("[STT] gRPC transcription successful: %s", result.Text)
+			return result, nil
 		}
 
 		// Log gRPC failure and fall back to CLI
@@ -234,18 +335,35 @@ func (s *STTService) TranscribeAudioWithLanguage(ctx context.Context, audioData
 		s.mu.Unlock()
 	}
 
+	// Use the managed whisper-server while it is healthy
+	s.mu.RLock()
+	server := s.server
+	s.mu.RUnlock()
+
+	if server != nil {
+		if client := server.Client(); client != nil {
+			result, err := client.TranscribeDetailed(ctx, audioData, s.resolveLanguage(language))
+			if err == nil {
+				log.Printf("[STT] whisper-server transcription successful: %s", result.Text)
+				return result, nil
+			}
+			// The supervisor restarts the server if it is down; use the CLI meanwhile
+			log.Printf("[STT] whisper-server transcription failed, falling back to CLI: %v", err)
+		}
+	}
+
 	// Fallback to CLI mode (existing implementation)
 	log.Println("[STT] Using CLI mode for transcription")
 	samples, err := s.convertAudioToSamples(audioData)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert audio: %w", err)
+		return nil, fmt.Errorf("failed to convert audio: %w", err)
 	}
 
 	if len(samples) == 0 {
-		return "", nil
+		return &Transcription{}, nil
 	}
 
-	return s.transcribeDirectlyWithLanguage(ctx, samples, language)
+	return s.transcribeDirectlyWithLanguage(ctx, samples, language, "")
 }
 
 // convertAudioToSamples converts byte audio data to float32 samples
@@ -368,95 +486,87 @@ func hasCUDALibraries() bool {
 	return true
 }
 
-// transcribeDirectlyWithLanguage performs direct transcription using whisper.cpp binary
-func (s *STTService) transcribeDirectlyWithLanguage(ctx context.Context, samples []float32, language string) (string, error) {
-	log.Printf("Direct transcription: processing %d audio samples", len(samples))
-	
-	if len(samples) == 0 {
-		return "", nil
+// startServer launches the managed whisper-server if the binary ships one
+func (s *STTService) startServer(modelPath string) {
+	caps, err := s.capabilities(context.Background())
+	if err != nil {
+		log.Printf("Whisper server not started: %v", err)
+		return
+	}
+	if caps.ServerPath == "" {
+		log.Println("No whisper-server binary found, transcribing with the CLI")
+		return
+	}
+	if !s.assetManager.IsAssetAvailable(modelPath) {
+		log.Printf("Whisper server not started: model not found at %s", modelPath)
+		return
 	}
-	
-	var whisperPath string
-	embeddedBinaryPath := s.assetManager.GetBinaryPath("whisper")
-	if s.assetManager.IsAssetAvailable(embeddedBinaryPath) {
-		whisperPath = embeddedBinaryPath
-	} else {
-		possiblePaths := []string{
-			"bin/whisper-cli.exe",
-			"bin/whisper-command.exe",
-			"bin/main.exe",
-			"bin/whisper.exe",
-		}
 
-		if runtime.GOOS != "windows" {
-			possiblePaths = []string{
-				"bin/whisper-cli",
-				"bin/whisper-command",
-				"bin/main",
-				"bin/whisper",
-			}
-		}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		for _, path := range possiblePaths {
-			if _, err := os.Stat(path); err == nil {
-				whisperPath = path
-				break
-			}
-		}
+	// A model switch may have happened while the binary was being probed
+	if !s.ready || s.server != nil || modelPath != s.modelPath {
+		return
 	}
+	s.server = startManagedServer(caps.ServerPath, modelPath, s.resolveLanguage(""), caps.GPU)
+	s.info.Metadata["mode"] = "server"
+}
 
-	if whisperPath == "" {
-		if downloadErr := s.downloadWhisperBinary(ctx); downloadErr != nil {
-			return "", fmt.Errorf("no whisper binary found and download failed: %w", downloadErr)
-		}
-		
-		possiblePaths := []string{
-			"bin/whisper-cli.exe",
-			"bin/whisper-command.exe", 
-			"bin/main.exe",
-			"bin/whisper.exe",
-		}
+// resolveLanguage returns the language to transcribe in, defaulting to the configured one
+func (s *STTService) resolveLanguage(language string) string {
+	if language == "" {
+		return s.config.Language
+	}
+	return language
+}
 
-		if runtime.GOOS != "windows" {
-			possiblePaths = []string{
-				"bin/whisper-cli",
-				"bin/whisper-command",
-				"bin/main",
-				"bin/whisper",
-			}
-		}
+// transcribeDirectlyWithLanguage performs direct transcription using whisper.cpp
+// binary; an empty model path uses the active model
+func (s *STTService) transcribeDirectlyWithLanguage(ctx context.Context, samples []float32, language, modelPath string) (*Transcription, error) {
+	log.Printf("Direct transcription: processing %d audio samples", len(samples))
 
-		for _, path := range possiblePaths {
-			if _, err := os.Stat(path); err == nil {
-				whisperPath = path
-				break
-			}
-		}
-		
-		if whisperPath == "" {
-			return "", fmt.Errorf("no whisper binary found even after download attempt")
-		}
+	if len(samples) == 0 {
+		return &Transcription{}, nil
 	}
-	
+
+	caps, err := s.capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	whisperPath := caps.Path
+
 	tmpDir := os.TempDir()
 	inputFile := filepath.Join(tmpDir, fmt.Sprintf("whisper_direct_%d.wav", time.Now().UnixNano()))
-	outputFile := filepath.Join(tmpDir, fmt.Sprintf("whisper_direct_%d.txt", time.Now().UnixNano()))
+	outputBase := filepath.Join(tmpDir, fmt.Sprintf("whisper_direct_%d", time.Now().UnixNano()))
+
+	// Prefer JSON output, which carries segment timing, token probabilities
+	// and the detected language
+	outputFile := outputBase + ".txt"
+	if caps.FullJSON || caps.OutputJSON {
+		outputFile = outputBase + ".json"
+	}
 	
 	defer os.Remove(inputFile)
 	defer os.Remove(outputFile)
 	
 	if err := s.writeWAVFile(inputFile, samples); err != nil {
-		return "", fmt.Errorf("failed to write WAV file: %w", err)
+		return nil, fmt.Errorf("failed to write WAV file: %w", err)
 	}
 	
 	// Get model path
-	modelPath := s.assetManager.GetModelPath("whisper")
-	
-	// Ensure model is available, download if needed
-	if !s.assetManager.IsAssetAvailable(modelPath) {
-		log.Printf("Whisper model not available at %s, downloading...", modelPath)
-		if err := s.downloadWhisperModel(ctx, modelPath); err != nil {
-			return "", fmt.Errorf("failed to download whisper model: %w", err)
+	if modelPath == "" {
+		s.mu.RLock()
+		model := s.model
+		modelPath = s.modelPath
+		s.mu.RUnlock()
+
+		// Ensure model is available, download if needed
+		if !s.assetManager.IsAssetAvailable(modelPath) {
+			log.Printf("Whisper model not available at %s, downloading...", modelPath)
+			if err := s.downloadWhisperModel(ctx, model); err != nil {
+				return nil, fmt.Errorf("failed to download whisper model: %w", err)
+			}
 		}
 	}
 	
@@ -468,71 +578,39 @@ func (s *STTService) transcribeDirectlyWithLanguage(ctx context.Context, samples
 		"--prompt", "",  // Empty initial prompt to avoid context from previous transcriptions
 	}
 
-	// Check if this binary supports -otxt flag by testing with --help
-	helpCmd := exec.Command(whisperPath, "--help")
-	helpOutput, _ := helpCmd.CombinedOutput()
-	supportsOtxt := strings.Contains(string(helpOutput), "-otxt") || strings.Contains(string(helpOutput), "otxt")
-	
-	if supportsOtxt {
+	switch {
+	case caps.FullJSON:
+		args = append(args, "-ojf")
+	case caps.OutputJSON:
+		args = append(args, "-oj")
+	case caps.OutputText:
 		args = append(args, "-otxt")
 	}
 	
-	args = append(args, "-of", strings.TrimSuffix(outputFile, ".txt"))
-	
-	langToUse := language
-	if langToUse == "" {
-		langToUse = s.config.Language
-	}
+	args = append(args, "-of", outputBase)
 	
+	langToUse := s.resolveLanguage(language)
 	if langToUse != "" && langToUse != "auto" {
 		args = append(args, "-l", langToUse)
 		log.Printf("Using language parameter: %s", langToUse)
 	}
 
-	// GPU detection and configuration
-	hasGPU := hasNVIDIAGPU()
-	hasCUDALibs := hasCUDALibraries()
-
-	if !hasGPU || !hasCUDALibs {
+	if !caps.GPU {
 		// Disable GPU if not available or libraries missing
 		args = append(args, "-ng")
-		if !hasGPU {
-			log.Println("No NVIDIA GPU detected - using CPU mode")
-		} else {
-			log.Println("CUDA libraries not found - using CPU mode")
-		}
-	} else {
-		log.Println("NVIDIA GPU with CUDA libraries detected - using GPU acceleration")
 	}
 
 	log.Printf("Executing whisper: %s %v", whisperPath, args)
 	
 	cmd := exec.CommandContext(ctx, whisperPath, args...)
-	
-	// Set library path for Linux to find shared libraries
-	if runtime.GOOS == "linux" {
-		binDir := filepath.Dir(whisperPath)
-		if cmd.Env == nil {
-			cmd.Env = os.Environ()
-		}
-		// Add bin directory to LD_LIBRARY_PATH
-		ldLibraryPath := binDir
-		for _, env := range cmd.Env {
-			if strings.HasPrefix(env, "LD_LIBRARY_PATH=") {
-				existingPath := strings.TrimPrefix(env, "LD_LIBRARY_PATH=")
-				ldLibraryPath = binDir + ":" + existingPath
-				break
-			}
-		}
-		cmd.Env = append(cmd.Env, "LD_LIBRARY_PATH="+ldLibraryPath)
-	}
+	cmd.Env = whisperEnv(whisperPath)
 	
 	output, err := cmd.CombinedOutput()
 	
 	log.Printf("Whisper command output: %s", string(output))
 	
 	if err != nil {
-		return "", fmt.Errorf("whisper command failed: %w (output: %s)", err, string(output))
+		return nil, fmt.Errorf("whisper command failed: %w (output: %s)", err, string(output))
 	}
 	
 	time.Sleep(100 * time.Millisecond)
@@ -541,61 +619,43 @@ func (s *STTService) transcribeDirectlyWithLanguage(ctx context.Context, samples
 	actualOutputFile := outputFile
 	
 	if _, err := os.Stat(actualOutputFile); os.IsNotExist(err) {
-		return "", fmt.Errorf("whisper output file not created: %s (command output: %s)", actualOutputFile, string(output))
+		return nil, fmt.Errorf("whisper output file not created: %s (command output: %s)", actualOutputFile, string(output))
 	}
 	
 	transcription, err := os.ReadFile(actualOutputFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to read transcription: %w", err)
+		return nil, fmt.Errorf("failed to read transcription: %w", err)
 	}
 	
 	defer os.Remove(actualOutputFile)
 	
-	text := strings.TrimSpace(string(transcription))
-	log.Printf("Direct transcription completed: '%s'", text)
+	result := &Transcription{Text: strings.TrimSpace(string(transcription))}
+	if strings.HasSuffix(actualOutputFile, ".json") {
+		if result, err = parseCLIJSON(transcription); err != nil {
+			return nil, err
+		}
+	}
+	if result.Language == "" && langToUse != "auto" {
+		result.Language = langToUse
+	}
+	result.Duration = float64(len(samples)) / 16000
+
+	log.Printf("Direct transcription completed: '%s'", result.Text)
 	
-	return text, nil
+	return result, nil
 }
 
 // downloadWhisperBinary downloads the whisper.cpp binary for the current platform
 func (s *STTService) downloadWhisperBinary(ctx context.Context) error {
-	var downloadURLs []string
-	var fileName string
-
-	switch runtime.GOOS {
-	case "windows":
-		if runtime.GOARCH == "amd64" || runtime.GOARCH == "x86_64" {
-			downloadURLs = []string{
-				"https://aliceai.ca/app_assets/whisper/whisper-windows.zip",
-			}
-			fileName = "whisper-windows.zip"
-		} else {
-			return fmt.Errorf("unsupported Windows architecture: %s", runtime.GOARCH)
-		}
-	case "darwin":
-		if runtime.GOARCH == "arm64" {
-			downloadURLs = []string{
-				"https://aliceai.ca/app_assets/whisper/whisper-macos-arm64.zip",
-			}
-			fileName = "whisper-macos-arm64.zip"
-		} else {
-			downloadURLs = []string{
-				"https://aliceai.ca/app_assets/whisper/whisper-macos-x64.zip",
-			}
-			fileName = "whisper-macos-x64.zip"
-		}
-	case "linux":
-		if runtime.GOARCH == "amd64" || runtime.GOARCH == "x86_64" {
-			downloadURLs = []string{
-				"https://aliceai.ca/app_assets/whisper/whisper-linux-x64.zip",
-			}
-			fileName = "whisper-linux-x64.zip"
-		} else {
-			return fmt.Errorf("unsupported Linux architecture: %s", runtime.GOARCH)
-		}
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	binary, ok := catalog.Default().Binary("whisper")
+	if !ok {
+		return fmt.Errorf("whisper binary missing from model catalog")
+	}
+	artifact, ok := binary.ForPlatform(runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return fmt.Errorf("unsupported platform: %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
+	fileName := artifact.File
 
 	log.Printf("Downloading Whisper binary for %s/%s", runtime.GOOS, runtime.GOARCH)
 
@@ -604,23 +664,9 @@ func (s *STTService) downloadWhisperBinary(ctx context.Context) error {
 		return fmt.Errorf("failed to create bin directory: %w", err)
 	}
 	downloadPath := filepath.Join("bin", fileName)
-	var lastErr error
-
-	for i, downloadURL := range downloadURLs {
-		log.Printf("Attempting binary download from source %d/%d: %s", i+1, len(downloadURLs), downloadURL)
 
-		if err := s.downloadFileWithRetry(downloadURL, downloadPath, 2); err != nil {
-			lastErr = err
-			log.Printf("Binary download source %d failed: %v", i+1, err)
-			continue
-		}
-
-		// Success - break out of loop
-		log.Printf("Binary download successful from source %d", i+1)
-		break
-	}
-	if _, err := os.Stat(downloadPath); err != nil {
-		return fmt.Errorf("failed to download whisper binary from any source: %w", lastErr)
+	if err := s.downloader.Fetch(ctx, artifact.Download("bin"), nil); err != nil {
+		return fmt.Errorf("failed to download whisper binary: %w", err)
 	}
 
 	// Handle different file types
@@ -657,12 +703,12 @@ func (s *STTService) extractWhisperBinary(zipPath string) error {
 
 	// Extract multiple useful whisper binaries and required DLLs/dylibs
 	extractedCount := 0
-	whisperBinaries := []string{"whisper-cli.exe", "whisper-command.exe", "main.exe", "whisper.exe"}
+	whisperBinaries := []string{"whisper-cli.exe", "whisper-command.exe", "main.exe", "whisper.exe", "whisper-server.exe"}
 	requiredDLLs := []string{"ggml-base.dll", "ggml-cpu.dll", "ggml.dll", "whisper.dll", "SDL2.dll"}
 	requiredDylibs := []string{} // dylib files for macOS
 
 	if runtime.GOOS != "windows" {
-		whisperBinaries = []string{"whisper-cli", "whisper-command", "main", "whisper"}
+		whisperBinaries = []string{"whisper-cli", "whisper-command", "main", "whisper", "whisper-server"}
 		requiredDLLs = []string{} // No DLLs needed on Unix
 		if runtime.GOOS == "darwin" {
 			// Required dylib files for macOS
@@ -766,122 +812,34 @@ func (s *STTService) extractSingleFile(file *zip.File, outputPath string) error
 	return nil
 }
 
-// downloadFileWithRetry downloads a file with retry logic
-func (s *STTService) downloadFileWithRetry(url, filepath string, maxRetries int) error {
-	var lastErr error
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if attempt > 1 {
-			// Exponential backoff: wait 2, 4, 8 seconds between retries
-			waitTime := time.Duration(1<<uint(attempt-2)) * 2 * time.Second
-			log.Printf("Retrying download in %v (attempt %d/%d)", waitTime, attempt, maxRetries)
-			time.Sleep(waitTime)
-		}
-
-		log.Printf("Download attempt %d/%d from: %s", attempt, maxRetries, url)
-
-		if err := s.downloadFileWithHeaders(url, filepath); err != nil {
-			lastErr = err
-			log.Printf("Attempt %d failed: %v", attempt, err)
-
-			// Clean up partial file on failure
-			if _, statErr := os.Stat(filepath); statErr == nil {
-				os.Remove(filepath)
-			}
-
-			continue
-		}
-		if info, err := os.Stat(filepath); err != nil {
-			lastErr = fmt.Errorf("downloaded file verification failed: %w", err)
-			continue
-		} else if info.Size() < 1000 {
-			lastErr = fmt.Errorf("downloaded file too small (%d bytes), likely an error page", info.Size())
-			os.Remove(filepath)
-			continue
-		}
-
-		log.Printf("Download successful on attempt %d", attempt)
-		return nil
-	}
-
-	return fmt.Errorf("download failed after %d attempts: %w", maxRetries, lastErr)
-}
-
-// downloadFileWithHeaders downloads a file with custom headers
-func (s *STTService) downloadFileWithHeaders(url, filepath string) error {
-	log.Printf("Starting download from: %s", url)
-
-	client := &http.Client{
-		Timeout: 15 * time.Minute,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
+// downloadWhisperModel downloads a Whisper model listed in the catalog
+func (s *STTService) downloadWhisperModel(ctx context.Context, name string) error {
+	files, err := s.ModelFilesFor(name)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("User-Agent", "AliceElectron/1.0 (compatible; file downloader)")
-	req.Header.Set("Accept", "application/octet-stream, */*")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to start download: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Handle response codes
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status: %d", resp.StatusCode)
-	}
-
-	// Create the file
-	out, err := os.Create(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return err
 	}
-	defer out.Close()
 
-	// Copy with progress reporting
-	written, err := io.Copy(out, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	for _, file := range files {
+		log.Printf("Downloading whisper model %s from %s", name, file.URLs[0])
+		if err := s.downloader.Fetch(ctx, file, nil); err != nil {
+			return fmt.Errorf("failed to download model: %w", err)
+		}
+		log.Printf("Successfully downloaded whisper model to: %s", file.DestPath)
 	}
-
-	log.Printf("Download completed: %s (%d bytes)", filepath, written)
 	return nil
 }
 
-// downloadWhisperModel downloads the base Whisper model
-func (s *STTService) downloadWhisperModel(ctx context.Context, modelPath string) error {
-	modelURL := "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.bin"
-	
-	log.Printf("Downloading whisper model from %s", modelURL)
-	
-	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
-		return fmt.Errorf("failed to create models directory: %w", err)
-	}
-	
-	resp, err := http.Get(modelURL)
-	if err != nil {
-		return fmt.Errorf("failed to download model: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download model: HTTP %d", resp.StatusCode)
-	}
-	
-	outFile, err := os.Create(modelPath)
-	if err != nil {
-		return fmt.Errorf("failed to create model file: %w", err)
-	}
-	defer outFile.Close()
-	
-	_, err = io.Copy(outFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save model: %w", err)
+// ReloadModel picks up a newly installed Whisper model without restarting the service
+func (s *STTService) ReloadModel(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.assetManager.IsAssetAvailable(s.modelPath) {
+		return fmt.Errorf("whisper model not found at %s", s.modelPath)
 	}
-	
-	log.Printf("Successfully downloaded whisper model to: %s", modelPath)
+	s.loadModel()
+
+	log.Printf("Whisper model reloaded: %s", s.modelPath)
 	return nil
 }
 
@@ -890,6 +848,11 @@ func (s *STTService) Shutdown(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.server != nil {
+		s.server.stop()
+		s.server = nil
+	}
+
 	s.ready = false
 	s.info.Status = "stopped"
 	s.info.LastUpdated = time.Now()
@@ -0,0 +1,52 @@
+package whisper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cues returns the segments to render as subtitles. A transcription without
+// segment timing becomes a single cue spanning the whole audio.
+func (t *Transcription) cues() []Segment {
+	cues := make([]Segment, 0, len(t.Segments))
+	for _, seg := range t.Segments {
+		if strings.TrimSpace(seg.Text) != "" {
+			cues = append(cues, seg)
+		}
+	}
+	if len(cues) == 0 && strings.TrimSpace(t.Text) != "" {
+		cues = append(cues, Segment{End: t.Duration, Text: t.Text})
+	}
+	return cues
+}
+
+// SRT renders the transcription as SubRip subtitles
+func (t *Transcription) SRT() string {
+	var sb strings.Builder
+	for i, cue := range t.cues() {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1, formatTimestamp(cue.Start, ","), formatTimestamp(cue.End, ","), strings.TrimSpace(cue.Text))
+	}
+	return sb.String()
+}
+
+// VTT renders the transcription as WebVTT subtitles
+func (t *Transcription) VTT() string {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+	for _, cue := range t.cues() {
+		fmt.Fprintf(&sb, "%s --> %s\n%s\n\n",
+			formatTimestamp(cue.Start, "."), formatTimestamp(cue.End, "."), strings.TrimSpace(cue.Text))
+	}
+	return sb.String()
+}
+
+// formatTimestamp formats seconds as HH:MM:SS followed by the separator and milliseconds
+func formatTimestamp(seconds float64, separator string) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	ms := int64(seconds*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, separator, ms%1000)
+}
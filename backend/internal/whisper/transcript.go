@@ -0,0 +1,229 @@
+package whisper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Transcription is a transcription result with timing and confidence details
+type Transcription struct {
+	Text     string  `json:"text"`
+	Language string  `json:"language,omitempty"`
+	Duration float64 `json:"duration"` // Audio length in seconds
+
+	// Confidence is the exponential of the average token log-probability (0 to 1)
+	Confidence float64   `json:"confidence"`
+	AvgLogprob float64   `json:"avg_logprob"`
+	Segments   []Segment `json:"segments,omitempty"`
+}
+
+// Segment is a span of transcribed speech
+type Segment struct {
+	ID         int     `json:"id"`
+	Start      float64 `json:"start"` // Seconds from the start of the audio
+	End        float64 `json:"end"`
+	Text       string  `json:"text"`
+	AvgLogprob float64 `json:"avg_logprob"`
+	Confidence float64 `json:"confidence"`
+	Words      []Word  `json:"words,omitempty"`
+}
+
+// Word is a single word with its timing
+type Word struct {
+	Word        string  `json:"word"`
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Probability float64 `json:"probability"`
+}
+
+// cliOutput is the document written by whisper-cli's -oj / -ojf options
+type cliOutput struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets cliOffsets `json:"offsets"`
+		Text    string     `json:"text"`
+		Tokens  []struct {
+			Text    string     `json:"text"`
+			Offsets cliOffsets `json:"offsets"`
+			P       float64    `json:"p"`
+		} `json:"tokens"` // Only present with -ojf
+	} `json:"transcription"`
+}
+
+// cliOffsets are whisper-cli timestamps in milliseconds
+type cliOffsets struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// parseCLIJSON converts whisper-cli JSON output into a Transcription
+func parseCLIJSON(data []byte) (*Transcription, error) {
+	var out cliOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("invalid whisper JSON output: %w", err)
+	}
+
+	t := &Transcription{Language: out.Result.Language}
+	for i, seg := range out.Transcription {
+		segment := Segment{
+			ID:    i,
+			Start: float64(seg.Offsets.From) / 1000,
+			End:   float64(seg.Offsets.To) / 1000,
+			Text:  strings.TrimSpace(seg.Text),
+		}
+
+		var logprobSum float64
+		var tokenCount int
+		for _, token := range seg.Tokens {
+			// Skip special tokens such as [_BEG_] and [_TT_150]
+			if strings.HasPrefix(token.Text, "[_") {
+				continue
+			}
+			logprobSum += math.Log(max(token.P, 1e-10))
+			tokenCount++
+
+			start := float64(token.Offsets.From) / 1000
+			end := float64(token.Offsets.To) / 1000
+
+			// Tokens are sub-word pieces; a leading space starts a new word
+			if len(segment.Words) == 0 || strings.HasPrefix(token.Text, " ") {
+				segment.Words = append(segment.Words, Word{
+					Word:        strings.TrimSpace(token.Text),
+					Start:       start,
+					End:         end,
+					Probability: token.P,
+				})
+				continue
+			}
+			word := &segment.Words[len(segment.Words)-1]
+			word.Word += token.Text
+			word.End = end
+			word.Probability = min(word.Probability, token.P)
+		}
+		if tokenCount > 0 {
+			segment.AvgLogprob = logprobSum / float64(tokenCount)
+			segment.Confidence = math.Exp(segment.AvgLogprob)
+		}
+
+		t.Segments = append(t.Segments, segment)
+	}
+
+	t.summarize()
+	return t, nil
+}
+
+// serverOutput is whisper-server's verbose_json response
+type serverOutput struct {
+	Text             string  `json:"text"`
+	Language         string  `json:"language"`
+	DetectedLanguage string  `json:"detected_language"`
+	Duration         float64 `json:"duration"`
+	Segments         []struct {
+		ID         int      `json:"id"`
+		Start      float64  `json:"start"`
+		End        float64  `json:"end"`
+		Text       string   `json:"text"`
+		AvgLogprob *float64 `json:"avg_logprob"`
+		Words      []Word   `json:"words"`
+	} `json:"segments"`
+}
+
+// parseServerJSON converts a whisper-server verbose_json response into a Transcription
+func parseServerJSON(data []byte) (*Transcription, error) {
+	var out serverOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("invalid whisper-server response: %w", err)
+	}
+
+	// "language" holds the full name (e.g. "english"); prefer the code when given
+	language := out.DetectedLanguage
+	if language == "" {
+		language = out.Language
+	}
+
+	t := &Transcription{
+		Text:     strings.TrimSpace(out.Text),
+		Language: language,
+		Duration: out.Duration,
+	}
+	for _, seg := range out.Segments {
+		words := make([]Word, 0, len(seg.Words))
+		for _, word := range seg.Words {
+			word.Word = strings.TrimSpace(word.Word)
+			if word.Word == "" || strings.HasPrefix(word.Word, "[_") {
+				continue
+			}
+			words = append(words, word)
+		}
+
+		segment := Segment{
+			ID:    seg.ID,
+			Start: seg.Start,
+			End:   seg.End,
+			Text:  strings.TrimSpace(seg.Text),
+			Words: words,
+		}
+		// Older servers leave out avg_logprob; keep the confidence unknown then
+		if seg.AvgLogprob != nil {
+			segment.AvgLogprob = *seg.AvgLogprob
+			segment.Confidence = math.Exp(*seg.AvgLogprob)
+		}
+		t.Segments = append(t.Segments, segment)
+	}
+
+	t.summarize()
+	return t, nil
+}
+
+// shift moves all timestamps later by offset seconds, for results of audio
+// that was trimmed before transcription
+func (t *Transcription) shift(offset float64) {
+	if offset == 0 {
+		return
+	}
+	for i := range t.Segments {
+		seg := &t.Segments[i]
+		seg.Start += offset
+		seg.End += offset
+		for j := range seg.Words {
+			seg.Words[j].Start += offset
+			seg.Words[j].End += offset
+		}
+	}
+}
+
+// summarize fills in the overall text and confidence from the segments
+func (t *Transcription) summarize() {
+	if len(t.Segments) == 0 {
+		return
+	}
+
+	if t.Text == "" {
+		texts := make([]string, 0, len(t.Segments))
+		for _, seg := range t.Segments {
+			if seg.Text != "" {
+				texts = append(texts, seg.Text)
+			}
+		}
+		t.Text = strings.Join(texts, " ")
+	}
+
+	// Weight each segment's log-probability by its length
+	var weighted, total float64
+	for _, seg := range t.Segments {
+		if seg.AvgLogprob == 0 && seg.Confidence == 0 {
+			continue // No probabilities for this segment
+		}
+		weight := max(seg.End-seg.Start, 0.01)
+		weighted += seg.AvgLogprob * weight
+		total += weight
+	}
+	if total > 0 {
+		t.AvgLogprob = weighted / total
+		t.Confidence = math.Exp(t.AvgLogprob)
+	}
+}
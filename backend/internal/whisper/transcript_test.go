@@ -0,0 +1,34 @@
+package whisper
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseServerJSON(t *testing.T) {
+	data := []byte(`{"text": " Hello there. Bye.", "language": "english", "detected_language": "en", "duration": 3,
+		"segments": [
+			{"id": 0, "start": 0, "end": 2, "text": " Hello there.", "avg_logprob": -0.5},
+			{"id": 1, "start": 2, "end": 3, "text": " Bye."}
+		]}`)
+
+	got, err := parseServerJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "Hello there. Bye." || got.Language != "en" || len(got.Segments) != 2 {
+		t.Fatalf("got %+v", got)
+	}
+
+	if seg := got.Segments[0]; seg.AvgLogprob != -0.5 || math.Abs(seg.Confidence-math.Exp(-0.5)) > 1e-9 {
+		t.Errorf("segment 0 has avg_logprob %f and confidence %f", seg.AvgLogprob, seg.Confidence)
+	}
+	// A segment without avg_logprob has no confidence rather than full confidence
+	if seg := got.Segments[1]; seg.AvgLogprob != 0 || seg.Confidence != 0 {
+		t.Errorf("segment 1 has avg_logprob %f and confidence %f, want none", seg.AvgLogprob, seg.Confidence)
+	}
+	// and is left out of the overall confidence
+	if got.AvgLogprob != -0.5 || math.Abs(got.Confidence-math.Exp(-0.5)) > 1e-9 {
+		t.Errorf("transcription has avg_logprob %f and confidence %f", got.AvgLogprob, got.Confidence)
+	}
+}
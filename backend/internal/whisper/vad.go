@@ -0,0 +1,160 @@
+package whisper
+
+import (
+	"math"
+)
+
+const (
+	// vadFrameSamples is the analysis frame (30ms at 16kHz), shared with streaming
+	vadFrameSamples = streamFrameSamples
+
+	// vadNoiseZCR rejects loud frames whose zero-crossing rate is that of
+	// broadband noise (white noise crosses zero on about half the samples)
+	vadNoiseZCR = 0.45
+
+	// vadFricativeZCR and vadFricativeEnergy let quiet, high-ZCR frames such as
+	// the "s" in "stop" extend a segment they border
+	vadFricativeZCR    = 0.2
+	vadFricativeEnergy = 0.25 // Fraction of the voice threshold
+
+	// vadFricativeFrames caps how far a segment can be extended that way (~240ms)
+	vadFricativeFrames = 8
+
+	// vadMinSpeechFrames drops clicks and pops shorter than ~90ms
+	vadMinSpeechFrames = 3
+
+	// vadMergeFrames joins segments separated by short pauses (~300ms)
+	vadMergeFrames = 10
+
+	// vadPadding is kept around each segment so word edges aren't clipped (seconds)
+	vadPadding = 0.1
+)
+
+// SpeechSegment is a span of detected speech in seconds from the start of the audio
+type SpeechSegment struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// DetectSpeech finds speech in 16kHz mono audio using short-time energy and
+// zero-crossing rate. Frames at least as loud as threshold (RMS) count as
+// speech unless they look like broadband noise; segments are then extended
+// over quiet unvoiced sounds, merged across short pauses and padded.
+func DetectSpeech(samples []float32, threshold float64) []SpeechSegment {
+	numFrames := len(samples) / vadFrameSamples
+	if numFrames == 0 {
+		return nil
+	}
+
+	energy := make([]float64, numFrames)
+	zcr := make([]float64, numFrames)
+	voiced := make([]bool, numFrames)
+	for i := range voiced {
+		frame := samples[i*vadFrameSamples : (i+1)*vadFrameSamples]
+		energy[i] = frameRMS(frame)
+		zcr[i] = zeroCrossingRate(frame)
+		voiced[i] = energy[i] >= threshold && zcr[i] < vadNoiseZCR
+	}
+
+	// Collect runs of voiced frames as [start, end) frame ranges
+	var runs [][2]int
+	for i := 0; i < numFrames; {
+		if !voiced[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < numFrames && voiced[i] {
+			i++
+		}
+		runs = append(runs, [2]int{start, i})
+	}
+
+	// Extend each run over adjacent fricatives, which are quiet but noisy
+	fricative := func(i int) bool {
+		return energy[i] >= threshold*vadFricativeEnergy && zcr[i] >= vadFricativeZCR && zcr[i] < vadNoiseZCR
+	}
+	for r := range runs {
+		for n := 0; n < vadFricativeFrames && runs[r][0] > 0 && fricative(runs[r][0]-1); n++ {
+			runs[r][0]--
+		}
+		for n := 0; n < vadFricativeFrames && runs[r][1] < numFrames && fricative(runs[r][1]); n++ {
+			runs[r][1]++
+		}
+	}
+
+	// Merge runs separated by short pauses, then drop ones too short to be speech
+	var merged [][2]int
+	for _, run := range runs {
+		if n := len(merged); n > 0 && run[0]-merged[n-1][1] <= vadMergeFrames {
+			merged[n-1][1] = max(merged[n-1][1], run[1])
+			continue
+		}
+		merged = append(merged, run)
+	}
+
+	duration := float64(len(samples)) / 16000
+	frameDuration := float64(vadFrameSamples) / 16000
+
+	var segments []SpeechSegment
+	for _, run := range merged {
+		if run[1]-run[0] < vadMinSpeechFrames {
+			continue
+		}
+		segment := SpeechSegment{
+			Start: math.Max(0, float64(run[0])*frameDuration-vadPadding),
+			End:   math.Min(duration, float64(run[1])*frameDuration+vadPadding),
+		}
+		// Padding can make neighbours overlap
+		if n := len(segments); n > 0 && segment.Start <= segments[n-1].End {
+			segments[n-1].End = segment.End
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+// speechBounds returns the sample range from the start of the first speech
+// segment to the end of the last one
+func speechBounds(samples []float32, threshold float64) (start, end int, ok bool) {
+	segments := DetectSpeech(samples, threshold)
+	if len(segments) == 0 {
+		return 0, 0, false
+	}
+
+	start = int(segments[0].Start * 16000)
+	end = min(len(samples), int(math.Ceil(segments[len(segments)-1].End*16000)))
+	return start, end, true
+}
+
+// isSpeechFrame reports whether a single frame looks like voiced speech
+func isSpeechFrame(frame []float32, threshold float64) bool {
+	return frameRMS(frame) >= threshold && zeroCrossingRate(frame) < vadNoiseZCR
+}
+
+// frameRMS returns the root-mean-square energy of a frame
+func frameRMS(frame []float32) float64 {
+	if len(frame) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range frame {
+		sum += float64(v) * float64(v)
+	}
+	return math.Sqrt(sum / float64(len(frame)))
+}
+
+// zeroCrossingRate returns the fraction of adjacent samples that change sign
+func zeroCrossingRate(frame []float32) float64 {
+	if len(frame) < 2 {
+		return 0
+	}
+	crossings := 0
+	for i := 1; i < len(frame); i++ {
+		if (frame[i] >= 0) != (frame[i-1] >= 0) {
+			crossings++
+		}
+	}
+	return float64(crossings) / float64(len(frame)-1)
+}
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"alice-backend/internal/api"
+	"alice-backend/internal/catalog"
 	"alice-backend/internal/config"
+	"alice-backend/internal/downloader"
 	"alice-backend/internal/models"
 	"alice-backend/internal/server"
 )
@@ -18,6 +20,28 @@ func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	// Replace the built-in model catalog if a custom one is configured
+	if cfg.Models.CatalogPath != "" {
+		modelCatalog, err := catalog.Load(cfg.Models.CatalogPath)
+		if err != nil {
+			slog.Error("Failed to load model catalog", "path", cfg.Models.CatalogPath, "error", err)
+			os.Exit(1)
+		}
+		catalog.SetDefault(modelCatalog)
+		slog.Info("Loaded model catalog", "path", cfg.Models.CatalogPath, "version", modelCatalog.Version)
+	}
+
+	// Load download checksums before any service starts fetching models
+	if cfg.Models.ManifestPath != "" {
+		manifest, err := downloader.LoadManifest(cfg.Models.ManifestPath)
+		if err != nil {
+			slog.Error("Failed to load model manifest", "path", cfg.Models.ManifestPath, "error", err)
+			os.Exit(1)
+		}
+		downloader.SetDefaultManifest(manifest)
+		slog.Info("Loaded model manifest", "path", cfg.Models.ManifestPath, "entries", len(manifest))
+	}
+
 	// Initialize model manager
 	modelManager := models.NewManager(cfg)
 
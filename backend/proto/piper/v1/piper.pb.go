@@ -121,12 +121,19 @@ func (x *HealthCheckResponse) GetAvailableVoices() []string {
 
 // SynthesizeRequest contains text and voice parameters for synthesis
 type SynthesizeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`     // Text to synthesize (required)
-	Voice         string                 `protobuf:"bytes,2,opt,name=voice,proto3" json:"voice,omitempty"`   // Voice model name (e.g., "en_US-amy-medium")
-	Speed         float32                `protobuf:"fixed32,3,opt,name=speed,proto3" json:"speed,omitempty"` // Playback speed multiplier (1.0 = normal, default)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Text    string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`       // Text to synthesize (required)
+	Voice   string                 `protobuf:"bytes,2,opt,name=voice,proto3" json:"voice,omitempty"`     // Voice model name (e.g., "en_US-amy-medium")
+	Speed   float32                `protobuf:"fixed32,3,opt,name=speed,proto3" json:"speed,omitempty"`   // Playback speed multiplier (1.0 = normal, default)
+	Speaker string                 `protobuf:"bytes,4,opt,name=speaker,proto3" json:"speaker,omitempty"` // Speaker name for multi-speaker voices (optional)
+	// Piper inference settings; unset fields use the voice's defaults
+	LengthScale     *float32 `protobuf:"fixed32,5,opt,name=length_scale,json=lengthScale,proto3,oneof" json:"length_scale,omitempty"`             // Phoneme duration scale, overrides speed (0.1 - 5.0)
+	NoiseScale      *float32 `protobuf:"fixed32,6,opt,name=noise_scale,json=noiseScale,proto3,oneof" json:"noise_scale,omitempty"`                // Generator noise (0.0 - 2.0)
+	NoiseW          *float32 `protobuf:"fixed32,7,opt,name=noise_w,json=noiseW,proto3,oneof" json:"noise_w,omitempty"`                            // Phoneme width noise (0.0 - 2.0)
+	SentenceSilence *float32 `protobuf:"fixed32,8,opt,name=sentence_silence,json=sentenceSilence,proto3,oneof" json:"sentence_silence,omitempty"` // Seconds of silence after each sentence (0.0 - 10.0)
+	SpeakerId       *int32   `protobuf:"varint,9,opt,name=speaker_id,json=speakerId,proto3,oneof" json:"speaker_id,omitempty"`                    // Numeric speaker id for multi-speaker voices, instead of speaker
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SynthesizeRequest) Reset() {
@@ -180,6 +187,48 @@ func (x *SynthesizeRequest) GetSpeed() float32 {
 	return 0
 }
 
+func (x *SynthesizeRequest) GetSpeaker() string {
+	if x != nil {
+		return x.Speaker
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetLengthScale() float32 {
+	if x != nil && x.LengthScale != nil {
+		return *x.LengthScale
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetNoiseScale() float32 {
+	if x != nil && x.NoiseScale != nil {
+		return *x.NoiseScale
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetNoiseW() float32 {
+	if x != nil && x.NoiseW != nil {
+		return *x.NoiseW
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetSentenceSilence() float32 {
+	if x != nil && x.SentenceSilence != nil {
+		return *x.SentenceSilence
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetSpeakerId() int32 {
+	if x != nil && x.SpeakerId != nil {
+		return *x.SpeakerId
+	}
+	return 0
+}
+
 // SynthesizeResponse contains the generated audio data
 type SynthesizeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -241,6 +290,99 @@ func (x *SynthesizeResponse) GetDurationMs() int64 {
 	return 0
 }
 
+// SynthesizeChunk contains the audio of a single sentence
+type SynthesizeChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                        // Zero-based sentence index
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                           // Sentence text
+	PcmData       []byte                 `protobuf:"bytes,3,opt,name=pcm_data,json=pcmData,proto3" json:"pcm_data,omitempty"`                      // Little-endian PCM samples without a WAV header
+	SampleRate    int32                  `protobuf:"varint,4,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`            // Sample rate in Hz
+	Channels      int32                  `protobuf:"varint,5,opt,name=channels,proto3" json:"channels,omitempty"`                                  // Number of interleaved channels
+	BitsPerSample int32                  `protobuf:"varint,6,opt,name=bits_per_sample,json=bitsPerSample,proto3" json:"bits_per_sample,omitempty"` // Bits per sample (typically 16)
+	Final         bool                   `protobuf:"varint,7,opt,name=final,proto3" json:"final,omitempty"`                                        // True for the last sentence of the request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SynthesizeChunk) Reset() {
+	*x = SynthesizeChunk{}
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SynthesizeChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynthesizeChunk) ProtoMessage() {}
+
+func (x *SynthesizeChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynthesizeChunk.ProtoReflect.Descriptor instead.
+func (*SynthesizeChunk) Descriptor() ([]byte, []int) {
+	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SynthesizeChunk) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SynthesizeChunk) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SynthesizeChunk) GetPcmData() []byte {
+	if x != nil {
+		return x.PcmData
+	}
+	return nil
+}
+
+func (x *SynthesizeChunk) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *SynthesizeChunk) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *SynthesizeChunk) GetBitsPerSample() int32 {
+	if x != nil {
+		return x.BitsPerSample
+	}
+	return 0
+}
+
+func (x *SynthesizeChunk) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
 // GetVoicesRequest is empty as no parameters are needed
 type GetVoicesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -250,7 +392,7 @@ type GetVoicesRequest struct {
 
 func (x *GetVoicesRequest) Reset() {
 	*x = GetVoicesRequest{}
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[4]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -262,7 +404,7 @@ func (x *GetVoicesRequest) String() string {
 func (*GetVoicesRequest) ProtoMessage() {}
 
 func (x *GetVoicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[4]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +417,7 @@ func (x *GetVoicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVoicesRequest.ProtoReflect.Descriptor instead.
 func (*GetVoicesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{4}
+	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{5}
 }
 
 // Voice represents metadata for a single voice model
@@ -287,13 +429,14 @@ type Voice struct {
 	Quality       string                 `protobuf:"bytes,4,opt,name=quality,proto3" json:"quality,omitempty"`                          // "x_low", "low", "medium", "high"
 	SampleRate    int32                  `protobuf:"varint,5,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // Sample rate in Hz
 	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                  // Human-readable description
+	Speakers      []string               `protobuf:"bytes,7,rep,name=speakers,proto3" json:"speakers,omitempty"`                        // Speaker names of multi-speaker voices, ordered by id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Voice) Reset() {
 	*x = Voice{}
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[5]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -305,7 +448,7 @@ func (x *Voice) String() string {
 func (*Voice) ProtoMessage() {}
 
 func (x *Voice) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[5]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,7 +461,7 @@ func (x *Voice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Voice.ProtoReflect.Descriptor instead.
 func (*Voice) Descriptor() ([]byte, []int) {
-	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{5}
+	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Voice) GetName() string {
@@ -363,6 +506,13 @@ func (x *Voice) GetDescription() string {
 	return ""
 }
 
+func (x *Voice) GetSpeakers() []string {
+	if x != nil {
+		return x.Speakers
+	}
+	return nil
+}
+
 // GetVoicesResponse contains the list of available voices
 type GetVoicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -373,7 +523,7 @@ type GetVoicesResponse struct {
 
 func (x *GetVoicesResponse) Reset() {
 	*x = GetVoicesResponse{}
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[6]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +535,7 @@ func (x *GetVoicesResponse) String() string {
 func (*GetVoicesResponse) ProtoMessage() {}
 
 func (x *GetVoicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_piper_v1_piper_proto_msgTypes[6]
+	mi := &file_proto_piper_v1_piper_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +548,7 @@ func (x *GetVoicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVoicesResponse.ProtoReflect.Descriptor instead.
 func (*GetVoicesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{6}
+	return file_proto_piper_v1_piper_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetVoicesResponse) GetVoices() []*Voice {
@@ -417,19 +567,42 @@ const file_proto_piper_v1_piper_proto_rawDesc = "" +
 	"\x13HealthCheckResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
 	"\fmodel_loaded\x18\x02 \x01(\bR\vmodelLoaded\x12)\n" +
-	"\x10available_voices\x18\x03 \x03(\tR\x0favailableVoices\"S\n" +
+	"\x10available_voices\x18\x03 \x03(\tR\x0favailableVoices\"\xfe\x02\n" +
 	"\x11SynthesizeRequest\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
 	"\x05voice\x18\x02 \x01(\tR\x05voice\x12\x14\n" +
-	"\x05speed\x18\x03 \x01(\x02R\x05speed\"u\n" +
+	"\x05speed\x18\x03 \x01(\x02R\x05speed\x12\x18\n" +
+	"\aspeaker\x18\x04 \x01(\tR\aspeaker\x12&\n" +
+	"\flength_scale\x18\x05 \x01(\x02H\x00R\vlengthScale\x88\x01\x01\x12$\n" +
+	"\vnoise_scale\x18\x06 \x01(\x02H\x01R\n" +
+	"noiseScale\x88\x01\x01\x12\x1c\n" +
+	"\anoise_w\x18\a \x01(\x02H\x02R\x06noiseW\x88\x01\x01\x12.\n" +
+	"\x10sentence_silence\x18\b \x01(\x02H\x03R\x0fsentenceSilence\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"speaker_id\x18\t \x01(\x05H\x04R\tspeakerId\x88\x01\x01B\x0f\n" +
+	"\r_length_scaleB\x0e\n" +
+	"\f_noise_scaleB\n" +
+	"\n" +
+	"\b_noise_wB\x13\n" +
+	"\x11_sentence_silenceB\r\n" +
+	"\v_speaker_id\"u\n" +
 	"\x12SynthesizeResponse\x12\x1d\n" +
 	"\n" +
 	"audio_data\x18\x01 \x01(\fR\taudioData\x12\x1f\n" +
 	"\vsample_rate\x18\x02 \x01(\x05R\n" +
 	"sampleRate\x12\x1f\n" +
 	"\vduration_ms\x18\x03 \x01(\x03R\n" +
-	"durationMs\"\x12\n" +
-	"\x10GetVoicesRequest\"\xac\x01\n" +
+	"durationMs\"\xd1\x01\n" +
+	"\x0fSynthesizeChunk\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x19\n" +
+	"\bpcm_data\x18\x03 \x01(\fR\apcmData\x12\x1f\n" +
+	"\vsample_rate\x18\x04 \x01(\x05R\n" +
+	"sampleRate\x12\x1a\n" +
+	"\bchannels\x18\x05 \x01(\x05R\bchannels\x12&\n" +
+	"\x0fbits_per_sample\x18\x06 \x01(\x05R\rbitsPerSample\x12\x14\n" +
+	"\x05final\x18\a \x01(\bR\x05final\"\x12\n" +
+	"\x10GetVoicesRequest\"\xc8\x01\n" +
 	"\x05Voice\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x16\n" +
@@ -437,13 +610,15 @@ const file_proto_piper_v1_piper_proto_rawDesc = "" +
 	"\aquality\x18\x04 \x01(\tR\aquality\x12\x1f\n" +
 	"\vsample_rate\x18\x05 \x01(\x05R\n" +
 	"sampleRate\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\"<\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bspeakers\x18\a \x03(\tR\bspeakers\"<\n" +
 	"\x11GetVoicesResponse\x12'\n" +
-	"\x06voices\x18\x01 \x03(\v2\x0f.piper.v1.VoiceR\x06voices2\xe9\x01\n" +
+	"\x06voices\x18\x01 \x03(\v2\x0f.piper.v1.VoiceR\x06voices2\xb7\x02\n" +
 	"\fPiperService\x12J\n" +
 	"\vHealthCheck\x12\x1c.piper.v1.HealthCheckRequest\x1a\x1d.piper.v1.HealthCheckResponse\x12G\n" +
 	"\n" +
-	"Synthesize\x12\x1b.piper.v1.SynthesizeRequest\x1a\x1c.piper.v1.SynthesizeResponse\x12D\n" +
+	"Synthesize\x12\x1b.piper.v1.SynthesizeRequest\x1a\x1c.piper.v1.SynthesizeResponse\x12L\n" +
+	"\x10SynthesizeStream\x12\x1b.piper.v1.SynthesizeRequest\x1a\x19.piper.v1.SynthesizeChunk0\x01\x12D\n" +
 	"\tGetVoices\x12\x1a.piper.v1.GetVoicesRequest\x1a\x1b.piper.v1.GetVoicesResponseB:Z8github.com/pmbstyle/alice/backend/proto/piper/v1;piperv1b\x06proto3"
 
 var (
@@ -458,26 +633,29 @@ func file_proto_piper_v1_piper_proto_rawDescGZIP() []byte {
 	return file_proto_piper_v1_piper_proto_rawDescData
 }
 
-var file_proto_piper_v1_piper_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_piper_v1_piper_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_proto_piper_v1_piper_proto_goTypes = []any{
 	(*HealthCheckRequest)(nil),  // 0: piper.v1.HealthCheckRequest
 	(*HealthCheckResponse)(nil), // 1: piper.v1.HealthCheckResponse
 	(*SynthesizeRequest)(nil),   // 2: piper.v1.SynthesizeRequest
 	(*SynthesizeResponse)(nil),  // 3: piper.v1.SynthesizeResponse
-	(*GetVoicesRequest)(nil),    // 4: piper.v1.GetVoicesRequest
-	(*Voice)(nil),               // 5: piper.v1.Voice
-	(*GetVoicesResponse)(nil),   // 6: piper.v1.GetVoicesResponse
+	(*SynthesizeChunk)(nil),     // 4: piper.v1.SynthesizeChunk
+	(*GetVoicesRequest)(nil),    // 5: piper.v1.GetVoicesRequest
+	(*Voice)(nil),               // 6: piper.v1.Voice
+	(*GetVoicesResponse)(nil),   // 7: piper.v1.GetVoicesResponse
 }
 var file_proto_piper_v1_piper_proto_depIdxs = []int32{
-	5, // 0: piper.v1.GetVoicesResponse.voices:type_name -> piper.v1.Voice
+	6, // 0: piper.v1.GetVoicesResponse.voices:type_name -> piper.v1.Voice
 	0, // 1: piper.v1.PiperService.HealthCheck:input_type -> piper.v1.HealthCheckRequest
 	2, // 2: piper.v1.PiperService.Synthesize:input_type -> piper.v1.SynthesizeRequest
-	4, // 3: piper.v1.PiperService.GetVoices:input_type -> piper.v1.GetVoicesRequest
-	1, // 4: piper.v1.PiperService.HealthCheck:output_type -> piper.v1.HealthCheckResponse
-	3, // 5: piper.v1.PiperService.Synthesize:output_type -> piper.v1.SynthesizeResponse
-	6, // 6: piper.v1.PiperService.GetVoices:output_type -> piper.v1.GetVoicesResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
+	2, // 3: piper.v1.PiperService.SynthesizeStream:input_type -> piper.v1.SynthesizeRequest
+	5, // 4: piper.v1.PiperService.GetVoices:input_type -> piper.v1.GetVoicesRequest
+	1, // 5: piper.v1.PiperService.HealthCheck:output_type -> piper.v1.HealthCheckResponse
+	3, // 6: piper.v1.PiperService.Synthesize:output_type -> piper.v1.SynthesizeResponse
+	4, // 7: piper.v1.PiperService.SynthesizeStream:output_type -> piper.v1.SynthesizeChunk
+	7, // 8: piper.v1.PiperService.GetVoices:output_type -> piper.v1.GetVoicesResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -488,13 +666,14 @@ func file_proto_piper_v1_piper_proto_init() {
 	if File_proto_piper_v1_piper_proto != nil {
 		return
 	}
+	file_proto_piper_v1_piper_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_piper_v1_piper_proto_rawDesc), len(file_proto_piper_v1_piper_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PiperService_HealthCheck_FullMethodName = "/piper.v1.PiperService/HealthCheck"
-	PiperService_Synthesize_FullMethodName  = "/piper.v1.PiperService/Synthesize"
-	PiperService_GetVoices_FullMethodName   = "/piper.v1.PiperService/GetVoices"
+	PiperService_HealthCheck_FullMethodName      = "/piper.v1.PiperService/HealthCheck"
+	PiperService_Synthesize_FullMethodName       = "/piper.v1.PiperService/Synthesize"
+	PiperService_SynthesizeStream_FullMethodName = "/piper.v1.PiperService/SynthesizeStream"
+	PiperService_GetVoices_FullMethodName        = "/piper.v1.PiperService/GetVoices"
 )
 
 // PiperServiceClient is the client API for PiperService service.
@@ -34,6 +35,9 @@ type PiperServiceClient interface {
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	// Synthesize converts text to speech audio
 	Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (*SynthesizeResponse, error)
+	// SynthesizeStream converts text to speech sentence by sentence, sending
+	// each sentence's audio as soon as it is ready
+	SynthesizeStream(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SynthesizeChunk], error)
 	// GetVoices returns the list of available voice models
 	GetVoices(ctx context.Context, in *GetVoicesRequest, opts ...grpc.CallOption) (*GetVoicesResponse, error)
 }
@@ -66,6 +70,25 @@ func (c *piperServiceClient) Synthesize(ctx context.Context, in *SynthesizeReque
 	return out, nil
 }
 
+func (c *piperServiceClient) SynthesizeStream(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SynthesizeChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PiperService_ServiceDesc.Streams[0], PiperService_SynthesizeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SynthesizeRequest, SynthesizeChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PiperService_SynthesizeStreamClient = grpc.ServerStreamingClient[SynthesizeChunk]
+
 func (c *piperServiceClient) GetVoices(ctx context.Context, in *GetVoicesRequest, opts ...grpc.CallOption) (*GetVoicesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetVoicesResponse)
@@ -86,6 +109,9 @@ type PiperServiceServer interface {
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	// Synthesize converts text to speech audio
 	Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error)
+	// SynthesizeStream converts text to speech sentence by sentence, sending
+	// each sentence's audio as soon as it is ready
+	SynthesizeStream(*SynthesizeRequest, grpc.ServerStreamingServer[SynthesizeChunk]) error
 	// GetVoices returns the list of available voice models
 	GetVoices(context.Context, *GetVoicesRequest) (*GetVoicesResponse, error)
 	mustEmbedUnimplementedPiperServiceServer()
@@ -104,6 +130,9 @@ func (UnimplementedPiperServiceServer) HealthCheck(context.Context, *HealthCheck
 func (UnimplementedPiperServiceServer) Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Synthesize not implemented")
 }
+func (UnimplementedPiperServiceServer) SynthesizeStream(*SynthesizeRequest, grpc.ServerStreamingServer[SynthesizeChunk]) error {
+	return status.Error(codes.Unimplemented, "method SynthesizeStream not implemented")
+}
 func (UnimplementedPiperServiceServer) GetVoices(context.Context, *GetVoicesRequest) (*GetVoicesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetVoices not implemented")
 }
@@ -164,6 +193,17 @@ func _PiperService_Synthesize_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PiperService_SynthesizeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SynthesizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PiperServiceServer).SynthesizeStream(m, &grpc.GenericServerStream[SynthesizeRequest, SynthesizeChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PiperService_SynthesizeStreamServer = grpc.ServerStreamingServer[SynthesizeChunk]
+
 func _PiperService_GetVoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetVoicesRequest)
 	if err := dec(in); err != nil {
@@ -202,6 +242,12 @@ var PiperService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PiperService_GetVoices_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SynthesizeStream",
+			Handler:       _PiperService_SynthesizeStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/piper/v1/piper.proto",
 }
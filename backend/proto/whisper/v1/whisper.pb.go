@@ -21,6 +21,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type StreamTranscribeResponse_EventType int32
+
+const (
+	StreamTranscribeResponse_EVENT_TYPE_UNSPECIFIED StreamTranscribeResponse_EventType = 0
+	// EVENT_TYPE_SPEECH_START marks the start of an utterance
+	StreamTranscribeResponse_EVENT_TYPE_SPEECH_START StreamTranscribeResponse_EventType = 1
+	// EVENT_TYPE_PARTIAL is an interim transcript of the utterance so far
+	StreamTranscribeResponse_EVENT_TYPE_PARTIAL StreamTranscribeResponse_EventType = 2
+	// EVENT_TYPE_FINAL is the transcript of a completed utterance
+	StreamTranscribeResponse_EVENT_TYPE_FINAL StreamTranscribeResponse_EventType = 3
+	// EVENT_TYPE_ERROR reports a failed transcription; the stream continues
+	StreamTranscribeResponse_EVENT_TYPE_ERROR StreamTranscribeResponse_EventType = 4
+)
+
+// Enum value maps for StreamTranscribeResponse_EventType.
+var (
+	StreamTranscribeResponse_EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_SPEECH_START",
+		2: "EVENT_TYPE_PARTIAL",
+		3: "EVENT_TYPE_FINAL",
+		4: "EVENT_TYPE_ERROR",
+	}
+	StreamTranscribeResponse_EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":  0,
+		"EVENT_TYPE_SPEECH_START": 1,
+		"EVENT_TYPE_PARTIAL":      2,
+		"EVENT_TYPE_FINAL":        3,
+		"EVENT_TYPE_ERROR":        4,
+	}
+)
+
+func (x StreamTranscribeResponse_EventType) Enum() *StreamTranscribeResponse_EventType {
+	p := new(StreamTranscribeResponse_EventType)
+	*p = x
+	return p
+}
+
+func (x StreamTranscribeResponse_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamTranscribeResponse_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_whisper_v1_whisper_proto_enumTypes[0].Descriptor()
+}
+
+func (StreamTranscribeResponse_EventType) Type() protoreflect.EnumType {
+	return &file_proto_whisper_v1_whisper_proto_enumTypes[0]
+}
+
+func (x StreamTranscribeResponse_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamTranscribeResponse_EventType.Descriptor instead.
+func (StreamTranscribeResponse_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{8, 0}
+}
+
 // HealthCheckRequest is the request for checking service health
 type HealthCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -197,7 +256,13 @@ type TranscribeResponse struct {
 	// confidence is the transcription confidence score (0.0 to 1.0)
 	Confidence float32 `protobuf:"fixed32,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
 	// duration_ms is the processing time in milliseconds
-	DurationMs    int64 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	DurationMs int64 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// segments are the timed spans of the transcription
+	Segments []*Segment `protobuf:"bytes,5,rep,name=segments,proto3" json:"segments,omitempty"`
+	// audio_duration is the length of the audio in seconds
+	AudioDuration float64 `protobuf:"fixed64,6,opt,name=audio_duration,json=audioDuration,proto3" json:"audio_duration,omitempty"`
+	// avg_logprob is the average token log-probability the confidence is derived from
+	AvgLogprob    float64 `protobuf:"fixed64,7,opt,name=avg_logprob,json=avgLogprob,proto3" json:"avg_logprob,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -260,6 +325,420 @@ func (x *TranscribeResponse) GetDurationMs() int64 {
 	return 0
 }
 
+func (x *TranscribeResponse) GetSegments() []*Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *TranscribeResponse) GetAudioDuration() float64 {
+	if x != nil {
+		return x.AudioDuration
+	}
+	return 0
+}
+
+func (x *TranscribeResponse) GetAvgLogprob() float64 {
+	if x != nil {
+		return x.AvgLogprob
+	}
+	return 0
+}
+
+// Segment is a timed span of transcribed speech
+type Segment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// start and end are offsets from the start of the audio in seconds
+	Start      float64 `protobuf:"fixed64,2,opt,name=start,proto3" json:"start,omitempty"`
+	End        float64 `protobuf:"fixed64,3,opt,name=end,proto3" json:"end,omitempty"`
+	Text       string  `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	AvgLogprob float64 `protobuf:"fixed64,5,opt,name=avg_logprob,json=avgLogprob,proto3" json:"avg_logprob,omitempty"`
+	// confidence is exp(avg_logprob)
+	Confidence float64 `protobuf:"fixed64,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// words are present when the backend produced token-level timestamps
+	Words         []*Word `protobuf:"bytes,7,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Segment) Reset() {
+	*x = Segment{}
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segment) ProtoMessage() {}
+
+func (x *Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segment.ProtoReflect.Descriptor instead.
+func (*Segment) Descriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Segment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Segment) GetStart() float64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Segment) GetEnd() float64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Segment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Segment) GetAvgLogprob() float64 {
+	if x != nil {
+		return x.AvgLogprob
+	}
+	return 0
+}
+
+func (x *Segment) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *Segment) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+// Word is a single transcribed word with its timing
+type Word struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Start         float64                `protobuf:"fixed64,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           float64                `protobuf:"fixed64,3,opt,name=end,proto3" json:"end,omitempty"`
+	Probability   float64                `protobuf:"fixed64,4,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Word) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Word) GetStart() float64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Word) GetEnd() float64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Word) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+// StreamTranscribeRequest is a message on a streaming transcription call. The
+// first message must carry the config; every following one carries audio.
+type StreamTranscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*StreamTranscribeRequest_Config
+	//	*StreamTranscribeRequest_AudioData
+	Request       isStreamTranscribeRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTranscribeRequest) Reset() {
+	*x = StreamTranscribeRequest{}
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTranscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTranscribeRequest) ProtoMessage() {}
+
+func (x *StreamTranscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTranscribeRequest.ProtoReflect.Descriptor instead.
+func (*StreamTranscribeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamTranscribeRequest) GetRequest() isStreamTranscribeRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *StreamTranscribeRequest) GetConfig() *StreamConfig {
+	if x != nil {
+		if x, ok := x.Request.(*StreamTranscribeRequest_Config); ok {
+			return x.Config
+		}
+	}
+	return nil
+}
+
+func (x *StreamTranscribeRequest) GetAudioData() []byte {
+	if x != nil {
+		if x, ok := x.Request.(*StreamTranscribeRequest_AudioData); ok {
+			return x.AudioData
+		}
+	}
+	return nil
+}
+
+type isStreamTranscribeRequest_Request interface {
+	isStreamTranscribeRequest_Request()
+}
+
+type StreamTranscribeRequest_Config struct {
+	Config *StreamConfig `protobuf:"bytes,1,opt,name=config,proto3,oneof"`
+}
+
+type StreamTranscribeRequest_AudioData struct {
+	// audio_data is a chunk of raw audio (PCM 16-bit, mono, at config.sample_rate)
+	AudioData []byte `protobuf:"bytes,2,opt,name=audio_data,json=audioData,proto3,oneof"`
+}
+
+func (*StreamTranscribeRequest_Config) isStreamTranscribeRequest_Request() {}
+
+func (*StreamTranscribeRequest_AudioData) isStreamTranscribeRequest_Request() {}
+
+// StreamConfig configures a streaming transcription call
+type StreamConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// language is the optional language code; empty means auto-detection
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// sample_rate is the audio sample rate in Hz (default: 16000)
+	SampleRate    int32 `protobuf:"varint,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamConfig) Reset() {
+	*x = StreamConfig{}
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamConfig) ProtoMessage() {}
+
+func (x *StreamConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamConfig.ProtoReflect.Descriptor instead.
+func (*StreamConfig) Descriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamConfig) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *StreamConfig) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+// StreamTranscribeResponse is an event on a streaming transcription call
+type StreamTranscribeResponse struct {
+	state protoimpl.MessageState             `protogen:"open.v1"`
+	Type  StreamTranscribeResponse_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=whisper.v1.StreamTranscribeResponse_EventType" json:"type,omitempty"`
+	Text  string                             `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// start and end are offsets from the start of the stream in seconds
+	Start float64 `protobuf:"fixed64,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   float64 `protobuf:"fixed64,4,opt,name=end,proto3" json:"end,omitempty"`
+	// result holds the full transcription of a final event
+	Result *TranscribeResponse `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// error describes a failed transcription
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTranscribeResponse) Reset() {
+	*x = StreamTranscribeResponse{}
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTranscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTranscribeResponse) ProtoMessage() {}
+
+func (x *StreamTranscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_whisper_v1_whisper_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTranscribeResponse.ProtoReflect.Descriptor instead.
+func (*StreamTranscribeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_whisper_v1_whisper_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamTranscribeResponse) GetType() StreamTranscribeResponse_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return StreamTranscribeResponse_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *StreamTranscribeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *StreamTranscribeResponse) GetStart() float64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *StreamTranscribeResponse) GetEnd() float64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *StreamTranscribeResponse) GetResult() *TranscribeResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *StreamTranscribeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_proto_whisper_v1_whisper_proto protoreflect.FileDescriptor
 
 const file_proto_whisper_v1_whisper_proto_rawDesc = "" +
@@ -277,7 +756,7 @@ const file_proto_whisper_v1_whisper_proto_rawDesc = "" +
 	"audio_data\x18\x01 \x01(\fR\taudioData\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x1f\n" +
 	"\vsample_rate\x18\x03 \x01(\x05R\n" +
-	"sampleRate\"\x96\x01\n" +
+	"sampleRate\"\x8f\x02\n" +
 	"\x12TranscribeResponse\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12+\n" +
 	"\x11language_detected\x18\x02 \x01(\tR\x10languageDetected\x12\x1e\n" +
@@ -285,11 +764,54 @@ const file_proto_whisper_v1_whisper_proto_rawDesc = "" +
 	"confidence\x18\x03 \x01(\x02R\n" +
 	"confidence\x12\x1f\n" +
 	"\vduration_ms\x18\x04 \x01(\x03R\n" +
-	"durationMs2\xad\x01\n" +
+	"durationMs\x12/\n" +
+	"\bsegments\x18\x05 \x03(\v2\x13.whisper.v1.SegmentR\bsegments\x12%\n" +
+	"\x0eaudio_duration\x18\x06 \x01(\x01R\raudioDuration\x12\x1f\n" +
+	"\vavg_logprob\x18\a \x01(\x01R\n" +
+	"avgLogprob\"\xbe\x01\n" +
+	"\aSegment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x01R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x01R\x03end\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x1f\n" +
+	"\vavg_logprob\x18\x05 \x01(\x01R\n" +
+	"avgLogprob\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x01R\n" +
+	"confidence\x12&\n" +
+	"\x05words\x18\a \x03(\v2\x10.whisper.v1.WordR\x05words\"d\n" +
+	"\x04Word\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x01R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x01R\x03end\x12 \n" +
+	"\vprobability\x18\x04 \x01(\x01R\vprobability\"y\n" +
+	"\x17StreamTranscribeRequest\x122\n" +
+	"\x06config\x18\x01 \x01(\v2\x18.whisper.v1.StreamConfigH\x00R\x06config\x12\x1f\n" +
+	"\n" +
+	"audio_data\x18\x02 \x01(\fH\x00R\taudioDataB\t\n" +
+	"\arequest\"K\n" +
+	"\fStreamConfig\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x1f\n" +
+	"\vsample_rate\x18\x02 \x01(\x05R\n" +
+	"sampleRate\"\xf3\x02\n" +
+	"\x18StreamTranscribeResponse\x12B\n" +
+	"\x04type\x18\x01 \x01(\x0e2..whisper.v1.StreamTranscribeResponse.EventTypeR\x04type\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x01R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x01R\x03end\x126\n" +
+	"\x06result\x18\x05 \x01(\v2\x1e.whisper.v1.TranscribeResponseR\x06result\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x88\x01\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17EVENT_TYPE_SPEECH_START\x10\x01\x12\x16\n" +
+	"\x12EVENT_TYPE_PARTIAL\x10\x02\x12\x14\n" +
+	"\x10EVENT_TYPE_FINAL\x10\x03\x12\x14\n" +
+	"\x10EVENT_TYPE_ERROR\x10\x042\x90\x02\n" +
 	"\x0eWhisperService\x12N\n" +
 	"\vHealthCheck\x12\x1e.whisper.v1.HealthCheckRequest\x1a\x1f.whisper.v1.HealthCheckResponse\x12K\n" +
 	"\n" +
-	"Transcribe\x12\x1d.whisper.v1.TranscribeRequest\x1a\x1e.whisper.v1.TranscribeResponseB>Z<github.com/pmbstyle/alice/backend/proto/whisper/v1;whisperv1b\x06proto3"
+	"Transcribe\x12\x1d.whisper.v1.TranscribeRequest\x1a\x1e.whisper.v1.TranscribeResponse\x12a\n" +
+	"\x10TranscribeStream\x12#.whisper.v1.StreamTranscribeRequest\x1a$.whisper.v1.StreamTranscribeResponse(\x010\x01B>Z<github.com/pmbstyle/alice/backend/proto/whisper/v1;whisperv1b\x06proto3"
 
 var (
 	file_proto_whisper_v1_whisper_proto_rawDescOnce sync.Once
@@ -303,23 +825,37 @@ func file_proto_whisper_v1_whisper_proto_rawDescGZIP() []byte {
 	return file_proto_whisper_v1_whisper_proto_rawDescData
 }
 
-var file_proto_whisper_v1_whisper_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_whisper_v1_whisper_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_whisper_v1_whisper_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_whisper_v1_whisper_proto_goTypes = []any{
-	(*HealthCheckRequest)(nil),  // 0: whisper.v1.HealthCheckRequest
-	(*HealthCheckResponse)(nil), // 1: whisper.v1.HealthCheckResponse
-	(*TranscribeRequest)(nil),   // 2: whisper.v1.TranscribeRequest
-	(*TranscribeResponse)(nil),  // 3: whisper.v1.TranscribeResponse
+	(StreamTranscribeResponse_EventType)(0), // 0: whisper.v1.StreamTranscribeResponse.EventType
+	(*HealthCheckRequest)(nil),              // 1: whisper.v1.HealthCheckRequest
+	(*HealthCheckResponse)(nil),             // 2: whisper.v1.HealthCheckResponse
+	(*TranscribeRequest)(nil),               // 3: whisper.v1.TranscribeRequest
+	(*TranscribeResponse)(nil),              // 4: whisper.v1.TranscribeResponse
+	(*Segment)(nil),                         // 5: whisper.v1.Segment
+	(*Word)(nil),                            // 6: whisper.v1.Word
+	(*StreamTranscribeRequest)(nil),         // 7: whisper.v1.StreamTranscribeRequest
+	(*StreamConfig)(nil),                    // 8: whisper.v1.StreamConfig
+	(*StreamTranscribeResponse)(nil),        // 9: whisper.v1.StreamTranscribeResponse
 }
 var file_proto_whisper_v1_whisper_proto_depIdxs = []int32{
-	0, // 0: whisper.v1.WhisperService.HealthCheck:input_type -> whisper.v1.HealthCheckRequest
-	2, // 1: whisper.v1.WhisperService.Transcribe:input_type -> whisper.v1.TranscribeRequest
-	1, // 2: whisper.v1.WhisperService.HealthCheck:output_type -> whisper.v1.HealthCheckResponse
-	3, // 3: whisper.v1.WhisperService.Transcribe:output_type -> whisper.v1.TranscribeResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5, // 0: whisper.v1.TranscribeResponse.segments:type_name -> whisper.v1.Segment
+	6, // 1: whisper.v1.Segment.words:type_name -> whisper.v1.Word
+	8, // 2: whisper.v1.StreamTranscribeRequest.config:type_name -> whisper.v1.StreamConfig
+	0, // 3: whisper.v1.StreamTranscribeResponse.type:type_name -> whisper.v1.StreamTranscribeResponse.EventType
+	4, // 4: whisper.v1.StreamTranscribeResponse.result:type_name -> whisper.v1.TranscribeResponse
+	1, // 5: whisper.v1.WhisperService.HealthCheck:input_type -> whisper.v1.HealthCheckRequest
+	3, // 6: whisper.v1.WhisperService.Transcribe:input_type -> whisper.v1.TranscribeRequest
+	7, // 7: whisper.v1.WhisperService.TranscribeStream:input_type -> whisper.v1.StreamTranscribeRequest
+	2, // 8: whisper.v1.WhisperService.HealthCheck:output_type -> whisper.v1.HealthCheckResponse
+	4, // 9: whisper.v1.WhisperService.Transcribe:output_type -> whisper.v1.TranscribeResponse
+	9, // 10: whisper.v1.WhisperService.TranscribeStream:output_type -> whisper.v1.StreamTranscribeResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_whisper_v1_whisper_proto_init() }
@@ -327,18 +863,23 @@ func file_proto_whisper_v1_whisper_proto_init() {
 	if File_proto_whisper_v1_whisper_proto != nil {
 		return
 	}
+	file_proto_whisper_v1_whisper_proto_msgTypes[6].OneofWrappers = []any{
+		(*StreamTranscribeRequest_Config)(nil),
+		(*StreamTranscribeRequest_AudioData)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_whisper_v1_whisper_proto_rawDesc), len(file_proto_whisper_v1_whisper_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_whisper_v1_whisper_proto_goTypes,
 		DependencyIndexes: file_proto_whisper_v1_whisper_proto_depIdxs,
+		EnumInfos:         file_proto_whisper_v1_whisper_proto_enumTypes,
 		MessageInfos:      file_proto_whisper_v1_whisper_proto_msgTypes,
 	}.Build()
 	File_proto_whisper_v1_whisper_proto = out.File
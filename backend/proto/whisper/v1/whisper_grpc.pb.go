@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WhisperService_HealthCheck_FullMethodName = "/whisper.v1.WhisperService/HealthCheck"
-	WhisperService_Transcribe_FullMethodName  = "/whisper.v1.WhisperService/Transcribe"
+	WhisperService_HealthCheck_FullMethodName      = "/whisper.v1.WhisperService/HealthCheck"
+	WhisperService_Transcribe_FullMethodName       = "/whisper.v1.WhisperService/Transcribe"
+	WhisperService_TranscribeStream_FullMethodName = "/whisper.v1.WhisperService/TranscribeStream"
 )
 
 // WhisperServiceClient is the client API for WhisperService service.
@@ -33,6 +34,9 @@ type WhisperServiceClient interface {
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	// Transcribe converts audio data to text
 	Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error)
+	// TranscribeStream transcribes live audio, detecting utterance boundaries on
+	// the server and returning partial and final transcripts as they are ready
+	TranscribeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTranscribeRequest, StreamTranscribeResponse], error)
 }
 
 type whisperServiceClient struct {
@@ -63,6 +67,19 @@ func (c *whisperServiceClient) Transcribe(ctx context.Context, in *TranscribeReq
 	return out, nil
 }
 
+func (c *whisperServiceClient) TranscribeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTranscribeRequest, StreamTranscribeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WhisperService_ServiceDesc.Streams[0], WhisperService_TranscribeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTranscribeRequest, StreamTranscribeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WhisperService_TranscribeStreamClient = grpc.BidiStreamingClient[StreamTranscribeRequest, StreamTranscribeResponse]
+
 // WhisperServiceServer is the server API for WhisperService service.
 // All implementations must embed UnimplementedWhisperServiceServer
 // for forward compatibility.
@@ -73,6 +90,9 @@ type WhisperServiceServer interface {
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	// Transcribe converts audio data to text
 	Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error)
+	// TranscribeStream transcribes live audio, detecting utterance boundaries on
+	// the server and returning partial and final transcripts as they are ready
+	TranscribeStream(grpc.BidiStreamingServer[StreamTranscribeRequest, StreamTranscribeResponse]) error
 	mustEmbedUnimplementedWhisperServiceServer()
 }
 
@@ -89,6 +109,9 @@ func (UnimplementedWhisperServiceServer) HealthCheck(context.Context, *HealthChe
 func (UnimplementedWhisperServiceServer) Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Transcribe not implemented")
 }
+func (UnimplementedWhisperServiceServer) TranscribeStream(grpc.BidiStreamingServer[StreamTranscribeRequest, StreamTranscribeResponse]) error {
+	return status.Error(codes.Unimplemented, "method TranscribeStream not implemented")
+}
 func (UnimplementedWhisperServiceServer) mustEmbedUnimplementedWhisperServiceServer() {}
 func (UnimplementedWhisperServiceServer) testEmbeddedByValue()                        {}
 
@@ -146,6 +169,13 @@ func _WhisperService_Transcribe_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WhisperService_TranscribeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WhisperServiceServer).TranscribeStream(&grpc.GenericServerStream[StreamTranscribeRequest, StreamTranscribeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WhisperService_TranscribeStreamServer = grpc.BidiStreamingServer[StreamTranscribeRequest, StreamTranscribeResponse]
+
 // WhisperService_ServiceDesc is the grpc.ServiceDesc for WhisperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -162,6 +192,13 @@ var WhisperService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _WhisperService_Transcribe_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TranscribeStream",
+			Handler:       _WhisperService_TranscribeStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "proto/whisper/v1/whisper.proto",
 }